@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/database"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+)
+
+// runApiKeys runs the api-keys subcommand, args are the arguments following "api-keys".
+func runApiKeys(args []string, out io.Writer) error {
+	_, args, err := subcommand("api-keys", args, "create")
+	if err != nil {
+		return err
+	}
+
+	var (
+		flags   = flag.NewFlagSet("api-keys create", flag.ContinueOnError)
+		name    = flags.String("name", "", "name of the key, recorded as the caller name in the audit log")
+		owner   = flags.Uint64("owner", 0, "id of the caller the key authenticates")
+		role    = flags.String("role", models.RoleViewer, "role granted to the key, see role_permissions")
+		expires = flags.Duration("expires", 0, "lifetime of the key, e.g. 720h, it never expires when 0")
+	)
+	flags.SetOutput(out)
+	flags.Usage = func() {
+		fmt.Fprintf(out, "usage: go-skeleton api-keys create -name NAME [flags]\n\ncreates an API key and prints it, only its hash is stored so it can not be shown again\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return errors.New("api-keys create: -name is required")
+	}
+	if *role == "" {
+		return errors.New("api-keys create: -role is required")
+	}
+
+	key, prefix, err := models.GenerateApiKey()
+	if err != nil {
+		return fmt.Errorf("api-keys create: %w", err)
+	}
+
+	apiKey := &models.ApiKey{
+		Name:    *name,
+		OwnerID: *owner,
+		Role:    *role,
+		Prefix:  prefix,
+		KeyHash: models.HashApiKey(key),
+	}
+	if *expires > 0 {
+		expiredAt := time.Now().Add(*expires)
+		apiKey.ExpiredAt = &expiredAt
+	}
+
+	var (
+		ctx    = context.Background()
+		cfg    = configs.GetInstance()
+		logger = driver.Logger(cfg)
+		cache  = driver.Redis(cfg)
+		db     = database.OpenDbConnection(cfg, logger)
+		repo   = app.WiringRepository(db, &cache, cfg, logger)
+	)
+	defer cache.Close()
+	defer database.CloseDbConnection(db, logger)
+
+	apiKey, err = repo.ApiKey.Create(ctx, apiKey)
+	if err != nil {
+		return fmt.Errorf("api-keys create: %w", err)
+	}
+
+	fmt.Fprintf(out, "id:     %d\nname:   %s\nrole:   %s\nprefix: %s\n", apiKey.ID, apiKey.Name, apiKey.Role, apiKey.Prefix)
+	if apiKey.ExpiredAt != nil {
+		fmt.Fprintf(out, "expiry: %s\n", apiKey.ExpiredAt.Format(time.RFC3339))
+	}
+	fmt.Fprintf(out, "key:    %s\n\nSend it as the %s header, it is not shown again.\n", key, middlewares.HeaderApiKey)
+	return nil
+}
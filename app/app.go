@@ -3,9 +3,11 @@ package app
 import (
 	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/controller"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/repository"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/service"
 	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -13,18 +15,49 @@ import (
 
 func WiringRepository(db *gorm.DB, cache *driver.RedisClient, cfg *configs.Configs, logger *logrus.Logger) *repository.Repositories {
 	return &repository.Repositories{
-		TeamMember: repository.NewTeamMemberRepository(db, *cache, cfg, logger),
+		ApiKey:     repository.NewApiKeyRepository(db, *cache, cfg, logger),
+		AuditLog:   repository.NewAuditLogRepository(db, cfg, logger),
+		Health:     repository.NewHealthRepository(db, *cache, cfg, logger),
+		Role:       repository.NewRoleRepository(db, *cache, cfg, logger),
+		TeamMember: repository.NewTeamMemberRepository(db, cfg, logger),
+		Tx:         repository.NewTxManager(db, cfg, logger),
 	}
 }
 
-func WiringService(repo *repository.Repositories, cfg *configs.Configs, logger *logrus.Logger) *service.Services {
+// WiringService builds the services and publishes the metrics of their caches, it is
+// called once per process since a cache name can only be published once.
+func WiringService(repo *repository.Repositories, cache *driver.RedisClient, cfg *configs.Configs, logger *logrus.Logger, lc *lifecycle.Manager, validator *validator.Validate) *service.Services {
+	teamMember := service.NewTeamMemberService(repo.TeamMember, repo.Tx, *cache, cfg, logger, lc, validator).(*service.TeamMemberSrv)
+	teamMember.Detail.Publish()
+	teamMember.Lists.Publish()
+
 	return &service.Services{
-		TeamMember: service.NewTeamMemberService(repo.TeamMember, cfg, logger),
+		AuditLog:      service.NewAuditLogService(repo.AuditLog, cfg, logger),
+		Authorization: service.NewAuthorizationService(repo.Role, cfg, logger, lc),
+		Health:        service.NewHealthService(repo.Health, cfg, logger, lc),
+		TeamMember:    teamMember,
 	}
 }
 
-func WiringController(srv *service.Services, cfg *configs.Configs, logger *logrus.Logger, validator *validator.Validate) *controller.Controllers {
+// WiringGuard builds the authenticators tried in order on protected routes, the role based
+// authorizer and the Idempotency-Key store, bearer tokens are only accepted when a JWT
+// secret is configured.
+func WiringGuard(repo *repository.Repositories, srv *service.Services, cache *driver.RedisClient, cfg *configs.Configs, logger *logrus.Logger) *middlewares.Guard {
+	authenticators := []middlewares.Authenticator{}
+	if cfg.Auth.JWTSecret != "" {
+		authenticators = append(authenticators, middlewares.NewJWTAuthenticator(cfg.Auth.JWTSecret, cfg.Auth.JWTIssuer, cfg.Auth.JWTLeeway))
+	}
+	authenticators = append(authenticators, middlewares.NewApiKeyAuthenticator(repo.ApiKey, logger))
+
+	guard := middlewares.NewGuard(middlewares.NewChainAuthenticator(authenticators...), srv.Authorization)
+	guard.Idempotency = middlewares.NewIdempotency(*cache, cfg.App.IdempotencyTTL, logger)
+	return guard
+}
+
+func WiringController(srv *service.Services, cfg *configs.Configs, logger *logrus.Logger, validator *validator.Validate, guard *middlewares.Guard) *controller.Controllers {
 	return &controller.Controllers{
-		TeamMember: controller.NewTeamMemberDelivery(srv.TeamMember, cfg, logger, validator),
+		AuditLog:   controller.NewAuditLogDelivery(srv.AuditLog, cfg, logger, guard),
+		Health:     controller.NewHealthDelivery(srv.Health, cfg, logger, guard),
+		TeamMember: controller.NewTeamMemberDelivery(srv.TeamMember, cfg, logger, validator, guard),
 	}
 }
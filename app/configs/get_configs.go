@@ -1,6 +1,9 @@
 package configs
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"log"
 	"os"
 	"strconv"
@@ -23,11 +26,23 @@ func GetInstance() *Configs {
 
 	configs = &Configs{
 		App: AppConfig{
-			Name:          getEnv("APP_NAME", "go-skeleton"),
-			Env:           getEnv("APP_ENV", "dev"),
-			Port:          getEnv("APP_PORT", "8000"),
-			BasicUsername: getEnv("BASIC_USERNAME", "user1"),
-			BasicPassword: getEnv("BASIC_PASSWORD", "Secret123"),
+			Name: getEnv("APP_NAME", "go-skeleton"),
+			Env:  getEnv("APP_ENV", "dev"),
+			Port: getEnv("APP_PORT", "8000"),
+
+			ShutdownTimeout:    GetAppShutdownTimeout(),
+			ShutdownDelay:      GetAppShutdownDelay(),
+			HealthCheckTimeout: GetAppHealthCheckTimeout(),
+
+			CursorSecret: GetAppCursorSecret(),
+
+			RequireIfMatch: getEnv("APP_REQUIRE_IF_MATCH", "false") == "true",
+			IdempotencyTTL: GetAppIdempotencyTTL(),
+		},
+		Auth: AuthConfig{
+			JWTSecret: getEnv("AUTH_JWT_SECRET", ""),
+			JWTIssuer: getEnv("AUTH_JWT_ISSUER", ""),
+			JWTLeeway: GetAuthJWTLeeway(),
 		},
 		DB: DbConfig{
 			Host:        getEnv("DB_HOST", "127.0.0.1"),
@@ -35,7 +50,10 @@ func GetInstance() *Configs {
 			DbName:      getEnv("DB_NAME", "my_db"),
 			Username:    getEnv("DB_USER", "postgres"),
 			Password:    getEnv("DB_PASS", ""),
-			DbIsMigrate: getEnv("DB_IS_MIGRATE", "true") == "true",
+			DbIsMigrate: getEnv("DB_IS_MIGRATE", "false") == "true",
+			DbIsSeed:    getEnv("DB_IS_SEED", "false") == "true",
+
+			CountEstimateThreshold: GetDbCountEstimateThreshold(),
 		},
 		Redis: RedisConfig{
 			Host:                getEnv("REDIS_HOST", "127.0.0.1"),
@@ -47,6 +65,8 @@ func GetInstance() *Configs {
 			PoolTimeout:         GetRedisPoolTimeout(),
 			MinIdleConn:         GetRedisMinIdleConn(),
 			DefaultCacheTimeOut: GetRedisDefaultCacheTimeOut(),
+
+			NegativeCacheTimeOut: GetRedisNegativeCacheTimeOut(),
 		},
 	}
 
@@ -83,6 +103,79 @@ func ServiceName() string {
 	return os.Getenv("APP_NAME")
 }
 
+func GetAppShutdownTimeout() time.Duration {
+	intVar, err := strconv.Atoi(getEnv("APP_SHUTDOWN_TIMEOUT", "15"))
+	if err != nil {
+		return 15 * time.Second
+	}
+
+	return time.Duration(intVar) * time.Second
+}
+
+func GetAppShutdownDelay() time.Duration {
+	intVar, err := strconv.Atoi(getEnv("APP_SHUTDOWN_DELAY", "0"))
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(intVar) * time.Second
+}
+
+func GetAppHealthCheckTimeout() time.Duration {
+	intVar, err := strconv.Atoi(getEnv("APP_HEALTH_CHECK_TIMEOUT", "2"))
+	if err != nil {
+		return 2 * time.Second
+	}
+
+	return time.Duration(intVar) * time.Second
+}
+
+func GetAppIdempotencyTTL() time.Duration {
+	intVar, err := strconv.Atoi(getEnv("APP_IDEMPOTENCY_TTL", "24"))
+	if err != nil {
+		return 24 * time.Hour
+	}
+
+	return time.Duration(intVar) * time.Hour
+}
+
+// GetAppCursorSecret is APP_CURSOR_SECRET, or a key derived from AUTH_JWT_SECRET when it is
+// empty so that the JWT signing key itself never signs a cursor, or empty without either.
+func GetAppCursorSecret() string {
+	if secret := getEnv("APP_CURSOR_SECRET", ""); secret != "" {
+		return secret
+	}
+
+	jwtSecret := getEnv("AUTH_JWT_SECRET", "")
+	if jwtSecret == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte("cursor"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func GetAuthJWTLeeway() time.Duration {
+	intVar, err := strconv.Atoi(getEnv("AUTH_JWT_LEEWAY", "30"))
+	if err != nil {
+		return 30 * time.Second
+	}
+
+	return time.Duration(intVar) * time.Second
+}
+
+// GetDbCountEstimateThreshold is the table size from which unfiltered lists report
+// the planner estimate instead of counting rows, 0 always counts.
+func GetDbCountEstimateThreshold() int64 {
+	intVar, err := strconv.ParseInt(getEnv("DB_COUNT_ESTIMATE_THRESHOLD", "0"), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return intVar
+}
+
 func GetRedisPort() int {
 	intVar, err := strconv.Atoi(getEnv("REDIS_HOST", "6379"))
 	if err != nil {
@@ -134,3 +227,12 @@ func GetRedisDefaultCacheTimeOut() time.Duration {
 
 	return time.Duration(intVar) * time.Minute
 }
+
+func GetRedisNegativeCacheTimeOut() time.Duration {
+	intVar, err := strconv.Atoi(getEnv("CACHE_NEGATIVE_TIMEOUT", "10"))
+	if err != nil {
+		return 10 * time.Second
+	}
+
+	return time.Duration(intVar) * time.Second
+}
@@ -3,17 +3,47 @@ package configs
 import "time"
 
 type Configs struct {
-	App   AppConfig
-	DB    DbConfig
-	Redis RedisConfig
+	App   AppConfig   `json:"app"`
+	Auth  AuthConfig  `json:"auth"`
+	DB    DbConfig    `json:"db"`
+	Redis RedisConfig `json:"redis"`
+}
+
+// redacted replaces the value of a secret that is set.
+const redacted = "[redacted]"
+
+// Redacted returns a copy of the configs with its secrets replaced, safe to print.
+func (c Configs) Redacted() Configs {
+	for _, secret := range []*string{&c.App.CursorSecret, &c.Auth.JWTSecret, &c.DB.Password, &c.Redis.Password} {
+		if *secret != "" {
+			*secret = redacted
+		}
+	}
+
+	return c
 }
 
 type AppConfig struct {
-	Name          string `json:"name"`
-	Env           string `json:"env"`
-	Port          string `json:"port"`
-	BasicUsername string `json:"basic_username"`
-	BasicPassword string `json:"basic_password"`
+	Name string `json:"name"`
+	Env  string `json:"env"`
+	Port string `json:"port"`
+
+	ShutdownTimeout    time.Duration `json:"shutdown_timeout"`
+	ShutdownDelay      time.Duration `json:"shutdown_delay"`
+	HealthCheckTimeout time.Duration `json:"health_check_timeout"`
+
+	CursorSecret string `json:"-"`
+
+	// RequireIfMatch rejects writes to a team member without an If-Match header.
+	RequireIfMatch bool `json:"require_if_match"`
+	// IdempotencyTTL is how long the response of a request sent with an Idempotency-Key is replayed.
+	IdempotencyTTL time.Duration `json:"idempotency_ttl"`
+}
+
+type AuthConfig struct {
+	JWTSecret string        `json:"jwt_secret"`
+	JWTIssuer string        `json:"jwt_issuer"`
+	JWTLeeway time.Duration `json:"jwt_leeway"`
 }
 
 type DbConfig struct {
@@ -23,7 +53,10 @@ type DbConfig struct {
 	Username    string `json:"username"`
 	Password    string `json:"password"`
 	DbIsMigrate bool   `json:"db_is_migrate"`
+	DbIsSeed    bool   `json:"db_is_seed"`
 	DebugMode   bool   `json:"debug_mode"`
+
+	CountEstimateThreshold int64 `json:"count_estimate_threshold"`
 }
 
 type RedisConfig struct {
@@ -36,4 +69,6 @@ type RedisConfig struct {
 	PoolTimeout         int           `json:"pool_timeout"`
 	MinIdleConn         int           `json:"min_idle_conn"`
 	DefaultCacheTimeOut time.Duration `json:"default_cache_time_out"`
+	// NegativeCacheTimeOut is how long a lookup of a missing record is remembered.
+	NegativeCacheTimeOut time.Duration `json:"negative_cache_time_out"`
 }
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"net/http"
+
+	help "github.com/adamnasrudin03/go-helpers"
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/service"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditLogController interface {
+	Mount(r *mux.Router)
+	GetList(w http.ResponseWriter, r *http.Request)
+}
+
+type AuditLogHandler struct {
+	Service service.AuditLogService
+	Cfg     *configs.Configs
+	Logger  *logrus.Logger
+	Guard   *middlewares.Guard
+}
+
+func NewAuditLogDelivery(
+	srv service.AuditLogService,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+	guard *middlewares.Guard,
+) AuditLogController {
+	return &AuditLogHandler{
+		Service: srv,
+		Cfg:     cfg,
+		Logger:  logger,
+		Guard:   guard,
+	}
+}
+
+func (c *AuditLogHandler) Mount(r *mux.Router) {
+	r.HandleFunc("", c.Guard.Protect(c.GetList, models.PermissionAuditLogRead)).Methods("GET")
+}
+
+func (c *AuditLogHandler) GetList(w http.ResponseWriter, r *http.Request) {
+	var (
+		opName  = "AuditLogController-GetList"
+		decoder = help.NewHttpDecoder()
+		input   dto.AuditLogListReq
+		err     error
+	)
+
+	err = decoder.Query(r, &input)
+	if err != nil {
+		c.Logger.Errorf("%v error bind json: %v ", opName, err)
+		renderError(w, c.Logger, response_mapper.ErrGetRequest())
+		return
+	}
+
+	res, err := c.Service.GetList(r.Context(), input)
+	if err != nil {
+		c.Logger.Errorf("%v error: %v ", opName, err)
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	renderPagination(w, res)
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/service/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditLogHandler_Routes(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		authz      stubAuthorizer
+		mockFunc   func(srv *mocks.AuditLogService)
+		wantStatus int
+	}{
+		{
+			name:       "without permission",
+			target:     "/v1/audit-logs",
+			authz:      stubAuthorizer{models.PermissionTeamMemberRead: true},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "invalid filter",
+			target: "/v1/audit-logs?sort_by=changes",
+			mockFunc: func(srv *mocks.AuditLogService) {
+				srv.On("GetList", mock.Anything, mock.Anything).Return(nil, response_mapper.ErrInvalidFormat("sort_by", "sort_by")).Once()
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "failed db",
+			target: "/v1/audit-logs",
+			mockFunc: func(srv *mocks.AuditLogService) {
+				srv.On("GetList", mock.Anything, mock.Anything).Return(nil, response_mapper.ErrDB()).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "success",
+			target: "/v1/audit-logs?entity=team_member&actor_id=7",
+			mockFunc: func(srv *mocks.AuditLogService) {
+				srv.On("GetList", mock.Anything, dto.AuditLogListReq{Entity: models.AuditEntityTeamMember, ActorID: 7}).
+					Return(&models.Pagination{Data: []models.AuditLog{}}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg    = &configs.Configs{}
+				srv    = &mocks.AuditLogService{}
+				authz  = tt.authz
+				router = mux.NewRouter()
+			)
+			if authz == nil {
+				authz = adminPermissions
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc(srv)
+			}
+			NewAuditLogDelivery(srv, cfg, driver.Logger(cfg), newTestGuard(authz)).
+				Mount(router.PathPrefix("/v1/audit-logs").Subrouter())
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("GET %v status = %v, want %v, body %s", tt.target, w.Code, tt.wantStatus, w.Body.String())
+			}
+			srv.AssertExpectations(t)
+		})
+	}
+}
@@ -2,5 +2,7 @@ package controller
 
 // Controllers all Controller object injected here
 type Controllers struct {
+	AuditLog   AuditLogController
+	Health     HealthController
 	TeamMember TeamMemberController
 }
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/sirupsen/logrus"
+)
+
+// dbErrorStatus maps a models.DBError kind to the status and error code of the response.
+var dbErrorStatus = map[string]struct {
+	status int
+	code   response_mapper.TypeError
+}{
+	models.DBErrUniqueViolation:      {status: http.StatusConflict, code: response_mapper.ErrConflict},
+	models.DBErrForeignKeyViolation:  {status: http.StatusUnprocessableEntity, code: response_mapper.ErrDatabase},
+	models.DBErrNotNullViolation:     {status: http.StatusUnprocessableEntity, code: response_mapper.ErrDatabase},
+	models.DBErrCheckViolation:       {status: http.StatusUnprocessableEntity, code: response_mapper.ErrDatabase},
+	models.DBErrSerializationFailure: {status: http.StatusServiceUnavailable, code: response_mapper.ErrDatabase},
+	models.DBErrVersionMismatch:      {status: http.StatusPreconditionFailed, code: response_mapper.ErrConflict},
+}
+
+// errorStatus maps a response_mapper error type to the response status, it differs from
+// response_mapper.RenderJSON where database errors are 422 instead of 500.
+var errorStatus = map[response_mapper.TypeError]int{
+	response_mapper.ErrForbidden:    http.StatusForbidden,
+	response_mapper.ErrUnauthorized: http.StatusUnauthorized,
+	response_mapper.ErrDatabase:     http.StatusInternalServerError,
+	response_mapper.ErrConflict:     http.StatusConflict,
+	response_mapper.ErrFromUseCase:  http.StatusUnprocessableEntity,
+	response_mapper.ErrValidation:   http.StatusBadRequest,
+	response_mapper.ErrNoFound:      http.StatusNotFound,
+	response_mapper.ErrUnknown:      http.StatusInternalServerError,
+}
+
+// renderError writes any error met by a handler with the status of its type, see errorResponse.
+func renderError(w http.ResponseWriter, logger *logrus.Logger, err error) {
+	status, respErr := errorResponse(logger, err)
+	if status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+	}
+	middlewares.WriteError(w, status, respErr)
+}
+
+// errorResponse returns the status and body of err, errors that are neither
+// a *models.DBError nor a *response_mapper.ResponseError are 500, and
+// middlewares.ErrAuthUnavailable is 503. Unknown errors are logged and answered
+// with middlewares.ErrInternal so their text is never sent.
+func errorResponse(logger *logrus.Logger, err error) (int, *response_mapper.ResponseError) {
+	var (
+		dbErr   *models.DBError
+		respErr *response_mapper.ResponseError
+	)
+	switch {
+	case errors.Is(err, middlewares.ErrAuthUnavailable):
+		return http.StatusServiceUnavailable, response_mapper.ErrDB()
+	case errors.As(err, &dbErr):
+		mapping, ok := dbErrorStatus[dbErr.Kind]
+		if !ok {
+			return http.StatusInternalServerError, response_mapper.ErrDB()
+		}
+
+		return mapping.status, response_mapper.NewError(mapping.code, response_mapper.NewResponseMultiLang(dbErrorMessage(dbErr)))
+	case errors.As(err, &respErr) && response_mapper.TypeError(respErr.Code) != response_mapper.ErrUnknown:
+		status, ok := errorStatus[response_mapper.TypeError(respErr.Code)]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		return status, respErr
+	default:
+		logger.Errorf("unexpected error: %v ", err)
+		return http.StatusInternalServerError, middlewares.ErrInternal()
+	}
+}
+
+func dbErrorMessage(err *models.DBError) response_mapper.MultiLanguages {
+	field := err.Field
+	if field == "" {
+		field = "data"
+	}
+
+	switch err.Kind {
+	case models.DBErrUniqueViolation:
+		return response_mapper.MultiLanguages{
+			ID: fmt.Sprintf("%s sudah digunakan", field),
+			EN: fmt.Sprintf("%s is already in use", field),
+		}
+	case models.DBErrForeignKeyViolation:
+		return response_mapper.MultiLanguages{
+			ID: fmt.Sprintf("%s merujuk ke data yang tidak ada", field),
+			EN: fmt.Sprintf("%s refers to a record that does not exist", field),
+		}
+	case models.DBErrNotNullViolation:
+		return response_mapper.MultiLanguages{
+			ID: fmt.Sprintf("%s wajib diisi", field),
+			EN: fmt.Sprintf("%s is required", field),
+		}
+	case models.DBErrCheckViolation:
+		return response_mapper.MultiLanguages{
+			ID: fmt.Sprintf("%s tidak valid", field),
+			EN: fmt.Sprintf("%s is invalid", field),
+		}
+	case models.DBErrVersionMismatch:
+		return response_mapper.MultiLanguages{
+			ID: "Data telah diubah oleh permintaan lain, silakan baca ulang",
+			EN: "The record was changed by another request, please read it again",
+		}
+	default:
+		return response_mapper.MultiLanguages{
+			ID: "Permintaan bentrok dengan permintaan lain, silakan coba lagi",
+			EN: "The request conflicted with another request, please try again",
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+)
+
+func TestRenderError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantEN     string
+	}{
+		{
+			name:       "response error keeps its mapping",
+			err:        response_mapper.ErrNotFound(),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "database error is 500",
+			err:        response_mapper.ErrDB(),
+			wantStatus: http.StatusInternalServerError,
+			wantEN:     "An error occurred while querying db",
+		},
+		{
+			name:       "validation error",
+			err:        response_mapper.ErrGetRequest(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "forbidden",
+			err:        response_mapper.ErrCannotHaveAccessResources(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "plain error",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantEN:     "Internal server error",
+		},
+		{
+			name:       "unknown response error",
+			err:        response_mapper.NewError(response_mapper.ErrUnknown, errors.New("json: unsupported type")),
+			wantStatus: http.StatusInternalServerError,
+			wantEN:     "Internal server error",
+		},
+		{
+			name:       "authorization unavailable",
+			err:        middlewares.ErrAuthUnavailable,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "unique violation",
+			err:        &models.DBError{Kind: models.DBErrUniqueViolation, Field: "email"},
+			wantStatus: http.StatusConflict,
+			wantEN:     "email is already in use",
+		},
+		{
+			name:       "not null violation",
+			err:        &models.DBError{Kind: models.DBErrNotNullViolation, Field: "name"},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantEN:     "name is required",
+		},
+		{
+			name:       "foreign key violation without field",
+			err:        &models.DBError{Kind: models.DBErrForeignKeyViolation},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantEN:     "data refers to a record that does not exist",
+		},
+		{
+			name:       "serialization failure",
+			err:        &models.DBError{Kind: models.DBErrSerializationFailure, Err: errors.New("could not serialize access")},
+			wantStatus: http.StatusServiceUnavailable,
+			wantEN:     "The request conflicted with another request, please try again",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			renderError(w, driver.Logger(&configs.Configs{}), tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("renderError() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if tt.wantEN == "" {
+				return
+			}
+
+			var body response_mapper.ResponseError
+			err := json.Unmarshal(w.Body.Bytes(), &body)
+			if err != nil {
+				t.Fatalf("failed decode body: %v", err)
+			}
+			if body.Message.EN != tt.wantEN {
+				t.Errorf("renderError() message = %v, want %v", body.Message.EN, tt.wantEN)
+			}
+			if body.Status != http.StatusText(tt.wantStatus) {
+				t.Errorf("renderError() body status = %v, want %v", body.Status, http.StatusText(tt.wantStatus))
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"expvar"
+	"net/http"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/service"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type HealthController interface {
+	Mount(r *mux.Router)
+	Liveness(w http.ResponseWriter, r *http.Request)
+	Readiness(w http.ResponseWriter, r *http.Request)
+}
+
+type HealthHandler struct {
+	Service service.HealthService
+	Cfg     *configs.Configs
+	Logger  *logrus.Logger
+	Guard   *middlewares.Guard
+}
+
+func NewHealthDelivery(
+	srv service.HealthService,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+	guard *middlewares.Guard,
+) HealthController {
+	return &HealthHandler{
+		Service: srv,
+		Cfg:     cfg,
+		Logger:  logger,
+		Guard:   guard,
+	}
+}
+
+func (c *HealthHandler) Mount(r *mux.Router) {
+	r.HandleFunc("/healthz", c.Liveness).Methods("GET")
+	r.HandleFunc("/readyz", c.Readiness).Methods("GET")
+	// runtime and cache metrics, see pkg/cache, they expose the command line and memory stats
+	r.HandleFunc("/debug/vars", c.Guard.Protect(expvar.Handler().ServeHTTP, models.PermissionMetricsRead)).Methods("GET")
+}
+
+func (c *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	res := c.Service.Liveness(r.Context())
+	response_mapper.RenderJSON(w, http.StatusOK, res)
+}
+
+func (c *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	var (
+		opName = "HealthController-Readiness"
+	)
+
+	res := c.Service.Readiness(r.Context())
+	if !res.IsUp() {
+		c.Logger.Errorf("%v not ready: %+v ", opName, res.Components)
+		response_mapper.RenderJSON(w, http.StatusServiceUnavailable, res)
+		return
+	}
+
+	response_mapper.RenderJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/gorilla/mux"
+)
+
+func TestHealthHandler_DebugVars(t *testing.T) {
+	tests := []struct {
+		name       string
+		authz      stubAuthorizer
+		wantStatus int
+	}{
+		{
+			name:       "without permission",
+			authz:      stubAuthorizer{models.PermissionTeamMemberRead: true},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "with permission",
+			authz:      adminPermissions,
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg    = &configs.Configs{}
+				router = mux.NewRouter()
+			)
+			NewHealthDelivery(nil, cfg, driver.Logger(cfg), newTestGuard(tt.authz)).Mount(router)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("GET /debug/vars status = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+)
+
+// etag is the strong entity tag of a version.
+func etag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// isNotModified reports whether the If-None-Match header of r matches tag, weak tags included.
+func isNotModified(r *http.Request, tag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// noCache reports whether the Cache-Control header of r asks for a response not served from the cache.
+func noCache(r *http.Request) bool {
+	for _, directive := range strings.Split(r.Header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion returns the version the If-Match header of r requires, 0 for any version.
+// Without the header it writes 428 when c.Cfg.App.RequireIfMatch is set, and 412 when the
+// header is not "*" or one strong ETag, returning false in both cases.
+func (c *TeamMemberHandler) ifMatchVersion(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	switch {
+	case header == "" && c.Cfg.App.RequireIfMatch:
+		writePreconditionRequired(w)
+		return 0, false
+	case header == "" || header == "*":
+		return 0, true
+	}
+
+	// weak tags never match If-Match
+	version, err := strconv.ParseUint(strings.Trim(header, `"`), 10, 64)
+	if err != nil || version == 0 || header != etag(version) {
+		middlewares.WriteError(w, http.StatusPreconditionFailed, response_mapper.NewError(response_mapper.ErrConflict, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: "If-Match harus berisi satu ETag",
+				EN: "If-Match must hold one ETag",
+			},
+		)))
+		return 0, false
+	}
+
+	return version, true
+}
+
+func writePreconditionRequired(w http.ResponseWriter) {
+	middlewares.WriteError(w, http.StatusPreconditionRequired, response_mapper.NewError(response_mapper.ErrValidation, response_mapper.NewResponseMultiLang(
+		response_mapper.MultiLanguages{
+			ID: "Header If-Match wajib diisi",
+			EN: "If-Match header is required",
+		},
+	)))
+}
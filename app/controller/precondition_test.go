@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/service/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTeamMemberHandler_Preconditions(t *testing.T) {
+	var (
+		teamMember = &models.TeamMember{ID: 1, Name: "adam", UsernameGithub: "adamnasrudin03", Email: "adam@example.com", Version: 3}
+		updateBody = `{"name":"adam","username_github":"adamnasrudin03","email":"adam@example.com"}`
+	)
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		header         map[string]string
+		requireIfMatch bool
+		mockFunc       func(srv *mocks.TeamMemberService)
+		wantStatus     int
+		wantETag       string
+	}{
+		{
+			name:   "detail with etag",
+			method: http.MethodGet,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetByID", mock.Anything, uint64(1)).Return(teamMember, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"3"`,
+		},
+		{
+			name:   "detail not modified",
+			method: http.MethodGet,
+			header: map[string]string{"If-None-Match": `"2", W/"3"`},
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetByID", mock.Anything, uint64(1)).Return(teamMember, nil).Once()
+			},
+			wantStatus: http.StatusNotModified,
+			wantETag:   `"3"`,
+		},
+		{
+			name:   "detail modified",
+			method: http.MethodGet,
+			header: map[string]string{"If-None-Match": `"2"`},
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetByID", mock.Anything, uint64(1)).Return(teamMember, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"3"`,
+		},
+		{
+			name:           "delete without required if match",
+			method:         http.MethodDelete,
+			requireIfMatch: true,
+			wantStatus:     http.StatusPreconditionRequired,
+		},
+		{
+			name:       "delete with weak etag",
+			method:     http.MethodDelete,
+			header:     map[string]string{"If-Match": `W/"3"`},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "delete any version",
+			method:         http.MethodDelete,
+			header:         map[string]string{"If-Match": "*"},
+			requireIfMatch: true,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("DeleteByID", mock.Anything, uint64(1), uint64(0)).Return(nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "update stale version",
+			method: http.MethodPut,
+			body:   updateBody,
+			header: map[string]string{"If-Match": `"2"`},
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Update", mock.Anything, mock.MatchedBy(func(req dto.TeamMemberUpdateReq) bool {
+					return req.ID == 1 && req.Version == 2
+				})).Return(nil, models.NewVersionMismatch(1, 3, 2)).Once()
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "update returns the new etag",
+			method: http.MethodPut,
+			body:   updateBody,
+			header: map[string]string{"If-Match": `"3"`},
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				updated := *teamMember
+				updated.Version = 4
+				srv.On("Update", mock.Anything, mock.MatchedBy(func(req dto.TeamMemberUpdateReq) bool {
+					return req.ID == 1 && req.Version == 3
+				})).Return(&updated, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"4"`,
+		},
+		{
+			name:   "patch returns the new etag",
+			method: http.MethodPatch,
+			body:   `{"name":"adam"}`,
+			header: map[string]string{"If-Match": `"3"`, "Content-Type": dto.ContentTypeMergePatch},
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				updated := *teamMember
+				updated.Version = 4
+				srv.On("Patch", mock.Anything, mock.MatchedBy(func(req dto.TeamMemberPatchReq) bool {
+					return req.ID == 1 && req.Version == 3
+				})).Return(&updated, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"4"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg    = &configs.Configs{App: configs.AppConfig{RequireIfMatch: tt.requireIfMatch}}
+				srv    = &mocks.TeamMemberService{}
+				router = mux.NewRouter()
+			)
+			if tt.mockFunc != nil {
+				tt.mockFunc(srv)
+			}
+			NewTeamMemberDelivery(srv, cfg, driver.Logger(cfg), validator.New(), newTestGuard(adminPermissions)).
+				Mount(router.PathPrefix("/v1/team-members").Subrouter())
+
+			r := httptest.NewRequest(tt.method, "/v1/team-members/1", strings.NewReader(tt.body))
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("%v status = %v, want %v, body %s", tt.method, w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("%v ETag = %v, want %v", tt.method, got, tt.wantETag)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() > 0 {
+				t.Errorf("%v body = %s, want none", tt.method, w.Body.String())
+			}
+			srv.AssertExpectations(t)
+		})
+	}
+}
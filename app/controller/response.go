@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"net/http"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// renderPagination writes a page in the response_mapper.ResponseDefault shape with models.Meta.
+func renderPagination(w http.ResponseWriter, res *models.Pagination) {
+	_ = response_mapper.WriteJSON(w, http.StatusOK, response_mapper.ResponseDefault{
+		Status: response_mapper.StatusMapping(http.StatusOK),
+		Meta:   res.Meta,
+		Data:   res.Data,
+	})
+}
@@ -2,6 +2,9 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,6 +14,7 @@ import (
 	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/service"
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
@@ -22,8 +26,13 @@ type TeamMemberController interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	GetDetail(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
+	Patch(w http.ResponseWriter, r *http.Request)
 	GetList(w http.ResponseWriter, r *http.Request)
+	Import(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+	Batch(w http.ResponseWriter, r *http.Request)
 }
 
 type TeamMemberHandler struct {
@@ -31,6 +40,7 @@ type TeamMemberHandler struct {
 	Cfg      *configs.Configs
 	Logger   *logrus.Logger
 	Validate *validator.Validate
+	Guard    *middlewares.Guard
 }
 
 func NewTeamMemberDelivery(
@@ -38,21 +48,50 @@ func NewTeamMemberDelivery(
 	cfg *configs.Configs,
 	logger *logrus.Logger,
 	validator *validator.Validate,
+	guard *middlewares.Guard,
 ) TeamMemberController {
 	return &TeamMemberHandler{
 		Service:  srv,
 		Cfg:      cfg,
 		Logger:   logger,
 		Validate: validator,
+		Guard:    guard,
 	}
 }
 
 func (c *TeamMemberHandler) Mount(r *mux.Router) {
-	r.HandleFunc("", middlewares.SetAuthBasic(c.Create, c.Cfg.App.BasicUsername, c.Cfg.App.BasicPassword)).Methods("POST")
-	r.HandleFunc("/{id}", middlewares.SetAuthBasic(c.Delete, c.Cfg.App.BasicUsername, c.Cfg.App.BasicPassword)).Methods("DELETE")
-	r.HandleFunc("/{id}", middlewares.SetAuthBasic(c.Update, c.Cfg.App.BasicUsername, c.Cfg.App.BasicPassword)).Methods("PUT")
-	r.HandleFunc("", c.GetList).Methods("GET")
-	r.HandleFunc("/{id}", c.GetDetail).Methods("GET")
+	routes := []struct {
+		method     string
+		path       string
+		handler    http.HandlerFunc
+		permission string
+		idempotent bool
+		// listsDeleted routes also require PermissionTeamMemberReadDeleted with include_deleted=true
+		listsDeleted bool
+	}{
+		{method: "POST", path: "", handler: c.Create, permission: models.PermissionTeamMemberCreate, idempotent: true},
+		{method: "POST", path: "/import", handler: c.Import, permission: models.PermissionTeamMemberCreate},
+		{method: "GET", path: "/export", handler: c.Export, listsDeleted: true},
+		// each operation of the batch is checked against its own permission by the handler
+		{method: "POST", path: "/batch", handler: c.Batch, permission: models.PermissionTeamMemberRead},
+		{method: "DELETE", path: "/{id}", handler: c.Delete, permission: models.PermissionTeamMemberDelete},
+		{method: "POST", path: "/{id}/restore", handler: c.Restore, permission: models.PermissionTeamMemberRestore},
+		{method: "PUT", path: "/{id}", handler: c.Update, permission: models.PermissionTeamMemberUpdate},
+		{method: "PATCH", path: "/{id}", handler: c.Patch, permission: models.PermissionTeamMemberUpdate},
+		{method: "GET", path: "", handler: c.GetList, listsDeleted: true},
+		{method: "GET", path: "/{id}", handler: c.GetDetail},
+	}
+
+	for _, v := range routes {
+		handler := v.handler
+		if v.idempotent {
+			handler = c.Guard.Idempotent(handler)
+		}
+		if v.listsDeleted {
+			handler = c.Guard.ProtectWhen(handler, models.PermissionTeamMemberReadDeleted, includeDeleted)
+		}
+		r.HandleFunc(v.path, c.Guard.Protect(handler, v.permission)).Methods(v.method)
+	}
 }
 
 func (c *TeamMemberHandler) getParamID(r *http.Request) (uint64, error) {
@@ -76,20 +115,20 @@ func (c *TeamMemberHandler) Create(w http.ResponseWriter, r *http.Request) {
 	err = json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
 		c.Logger.Errorf("%v error bind json: %v ", opName, err)
-		response_mapper.RenderJSON(w, http.StatusBadRequest, response_mapper.ErrGetRequest())
+		renderError(w, c.Logger, response_mapper.ErrGetRequest())
 		return
 	}
 
 	// validation input user
 	err = c.Validate.Struct(input)
 	if err != nil {
-		response_mapper.RenderJSON(w, http.StatusBadRequest, response_mapper.FormatValidationError(err))
+		renderError(w, c.Logger, response_mapper.FormatValidationError(err))
 		return
 	}
 
 	res, err := c.Service.Create(r.Context(), input)
 	if err != nil {
-		response_mapper.RenderJSON(w, http.StatusInternalServerError, err)
+		renderError(w, c.Logger, err)
 		return
 	}
 
@@ -104,14 +143,21 @@ func (c *TeamMemberHandler) GetDetail(w http.ResponseWriter, r *http.Request) {
 
 	id, err := c.getParamID(r)
 	if err != nil {
-		response_mapper.RenderJSON(w, http.StatusBadRequest, err)
+		renderError(w, c.Logger, err)
 		return
 	}
 
 	res, err := c.Service.GetByID(r.Context(), id)
 	if err != nil {
 		c.Logger.Errorf("%v error: %v ", opName, err)
-		response_mapper.RenderJSON(w, http.StatusInternalServerError, err)
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	tag := etag(res.Version)
+	w.Header().Set("ETag", tag)
+	if isNotModified(r, tag) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
@@ -126,14 +172,19 @@ func (c *TeamMemberHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	id, err := c.getParamID(r)
 	if err != nil {
-		response_mapper.RenderJSON(w, http.StatusBadRequest, err)
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	version, ok := c.ifMatchVersion(w, r)
+	if !ok {
 		return
 	}
 
-	err = c.Service.DeleteByID(r.Context(), id)
+	err = c.Service.DeleteByID(r.Context(), id, version)
 	if err != nil {
 		c.Logger.Errorf("%v error: %v ", opName, err)
-		response_mapper.RenderJSON(w, http.StatusInternalServerError, err)
+		renderError(w, c.Logger, err)
 		return
 	}
 
@@ -143,44 +194,126 @@ func (c *TeamMemberHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (c *TeamMemberHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	var (
+		opName = "TeamMemberController-Restore"
+		err    error
+	)
+
+	id, err := c.getParamID(r)
+	if err != nil {
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	err = c.Service.RestoreByID(r.Context(), id)
+	if err != nil {
+		c.Logger.Errorf("%v error: %v ", opName, err)
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	response_mapper.RenderJSON(w, http.StatusOK, response_mapper.MultiLanguages{
+		ID: "Anggota Tim Berhasil Dipulihkan",
+		EN: "Team Member Restored Successfully",
+	})
+}
+
 func (c *TeamMemberHandler) Update(w http.ResponseWriter, r *http.Request) {
 	var (
 		opName = "TeamMemberController-Update"
 		input  dto.TeamMemberUpdateReq
+		ok     bool
 		err    error
 	)
 
 	id, err := c.getParamID(r)
 	if err != nil {
-		response_mapper.RenderJSON(w, http.StatusBadRequest, err)
+		renderError(w, c.Logger, err)
 		return
 	}
 
 	err = json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
 		c.Logger.Errorf("%v error bind json: %v ", opName, err)
-		response_mapper.RenderJSON(w, http.StatusBadRequest, response_mapper.ErrGetRequest())
+		renderError(w, c.Logger, response_mapper.ErrGetRequest())
 		return
 	}
 	input.ID = id
+	input.Version, ok = c.ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
 	// validation input user
 	err = c.Validate.Struct(input)
 	if err != nil {
-		response_mapper.RenderJSON(w, http.StatusBadRequest, response_mapper.FormatValidationError(err))
+		renderError(w, c.Logger, response_mapper.FormatValidationError(err))
 		return
 	}
 
-	err = c.Service.Update(r.Context(), input)
+	res, err := c.Service.Update(r.Context(), input)
 	if err != nil {
 		c.Logger.Errorf("%v error: %v ", opName, err)
-		response_mapper.RenderJSON(w, http.StatusInternalServerError, err)
+		renderError(w, c.Logger, err)
 		return
 	}
 
-	response_mapper.RenderJSON(w, http.StatusOK, response_mapper.MultiLanguages{
-		ID: "Anggota Tim Berhasil Diperbarui",
-		EN: "Team Member Updated Successfully",
-	})
+	w.Header().Set("ETag", etag(res.Version))
+	response_mapper.RenderJSON(w, http.StatusOK, res)
+}
+
+// Patch changes the fields of the body, a JSON Merge Patch or, by its Content-Type, a JSON Patch.
+func (c *TeamMemberHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	var (
+		opName = "TeamMemberController-Patch"
+		err    error
+	)
+
+	id, err := c.getParamID(r)
+	if err != nil {
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		c.Logger.Errorf("%v error read body: %v ", opName, err)
+		renderError(w, c.Logger, response_mapper.ErrGetRequest())
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	patch, err := dto.NewTeamMemberPatch(mediaType, body)
+	if errors.Is(err, dto.ErrUnsupportedPatch) {
+		w.Header().Set("Accept-Patch", dto.ContentTypeMergePatch+", "+dto.ContentTypeJSONPatch)
+		middlewares.WriteError(w, http.StatusUnsupportedMediaType, response_mapper.NewError(response_mapper.ErrValidation, err))
+		return
+	}
+	if err != nil {
+		renderError(w, c.Logger, response_mapper.NewError(response_mapper.ErrValidation, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: err.Error(),
+				EN: err.Error(),
+			},
+		)))
+		return
+	}
+
+	version, ok := c.ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := c.Service.Patch(r.Context(), dto.TeamMemberPatchReq{ID: id, Patch: patch, Version: version})
+	if err != nil {
+		c.Logger.Errorf("%v error: %v ", opName, err)
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(res.Version))
+	response_mapper.RenderJSON(w, http.StatusOK, res)
 }
 
 func (c *TeamMemberHandler) GetList(w http.ResponseWriter, r *http.Request) {
@@ -194,16 +327,25 @@ func (c *TeamMemberHandler) GetList(w http.ResponseWriter, r *http.Request) {
 	err = decoder.Query(r, &input)
 	if err != nil {
 		c.Logger.Errorf("%v error bind json: %v ", opName, err)
-		response_mapper.RenderJSON(w, http.StatusBadRequest, response_mapper.ErrGetRequest())
+		renderError(w, c.Logger, response_mapper.ErrGetRequest())
 		return
 	}
+	input.Query = r.URL.Query()
+	input.NoCache = noCache(r)
 
 	res, err := c.Service.GetList(r.Context(), input)
 	if err != nil {
 		c.Logger.Errorf("%v error: %v ", opName, err)
-		response_mapper.RenderJSON(w, http.StatusInternalServerError, err)
+		renderError(w, c.Logger, err)
 		return
 	}
 
-	response_mapper.RenderJSON(w, http.StatusOK, res)
+	renderPagination(w, res)
+}
+
+// includeDeleted reports whether the list asks for deleted rows, an invalid include_deleted is
+// left to the handler to reject.
+func includeDeleted(r *http.Request) bool {
+	include, _ := strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+	return include
 }
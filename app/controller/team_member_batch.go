@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/sirupsen/logrus"
+)
+
+// batchOpPermissions are the permissions each batch operation needs.
+var batchOpPermissions = map[string]string{
+	dto.BatchOpCreate: models.PermissionTeamMemberCreate,
+	dto.BatchOpUpdate: models.PermissionTeamMemberUpdate,
+	dto.BatchOpDelete: models.PermissionTeamMemberDelete,
+}
+
+// batchSkippedErrors are the errors of atomic batch operations that did not fail themselves.
+var batchSkippedErrors = map[error]response_mapper.MultiLanguages{
+	dto.ErrBatchRolledBack: {
+		ID: "Dibatalkan karena operasi lain gagal",
+		EN: "Rolled back because another operation failed",
+	},
+	dto.ErrBatchNotRun: {
+		ID: "Tidak dijalankan karena operasi sebelumnya gagal",
+		EN: "Not run because an earlier operation failed",
+	},
+}
+
+// Batch runs the create, update and delete operations of the body, atomically unless
+// its mode is best_effort. The response is 200 when every operation succeeded and
+// 207 otherwise, with the status and error of each operation by index.
+func (c *TeamMemberHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	var (
+		opName = "TeamMemberController-Batch"
+		input  dto.TeamMemberBatchReq
+		err    error
+	)
+
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		c.Logger.Errorf("%v error bind json: %v ", opName, err)
+		renderError(w, c.Logger, response_mapper.ErrGetRequest())
+		return
+	}
+
+	err = input.Validate()
+	if err != nil {
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	err = c.checkBatchPermissions(r, input.Operations)
+	if err != nil {
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	// like If-Match on PUT and DELETE, updates and deletes must name the version they expect
+	for _, op := range input.Operations {
+		if c.Cfg.App.RequireIfMatch && op.Op != dto.BatchOpCreate && op.Version == 0 {
+			writePreconditionRequired(w)
+			return
+		}
+	}
+
+	res, err := c.Service.Batch(r.Context(), input)
+	if err != nil {
+		c.Logger.Errorf("%v error: %v ", opName, err)
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	status := http.StatusOK
+	for i := range res.Results {
+		result := &res.Results[i]
+		switch {
+		case result.Err == nil && result.Op == dto.BatchOpCreate:
+			result.Status = http.StatusCreated
+		case result.Err == nil:
+			result.Status = http.StatusOK
+		default:
+			status = http.StatusMultiStatus
+			result.Status, result.Error = batchErrorResponse(c.Logger, result.Err)
+		}
+	}
+
+	response_mapper.RenderJSON(w, status, res)
+}
+
+// checkBatchPermissions allows the batch only when the caller may run every one of its operations.
+func (c *TeamMemberHandler) checkBatchPermissions(r *http.Request, operations []dto.TeamMemberBatchOperation) error {
+	role := ""
+	if caller, ok := models.CallerFromContext(r.Context()); ok {
+		role = caller.Role
+	}
+
+	checked := map[string]bool{}
+	for _, op := range operations {
+		permission := batchOpPermissions[op.Op]
+		if checked[permission] {
+			continue
+		}
+		checked[permission] = true
+
+		allowed, err := c.Guard.Authz.HasPermission(r.Context(), role, permission)
+		if err != nil {
+			c.Logger.Errorf("TeamMemberController-checkBatchPermissions error: %v ", err)
+			return middlewares.ErrAuthUnavailable
+		}
+		if !allowed {
+			return response_mapper.ErrCannotHaveAccessResources()
+		}
+	}
+
+	return nil
+}
+
+// batchErrorResponse returns the status and message of a failed batch operation,
+// 424 for the operations of an atomic batch that failed because of another one.
+func batchErrorResponse(logger *logrus.Logger, err error) (int, *response_mapper.MultiLanguages) {
+	for skipped, message := range batchSkippedErrors {
+		if errors.Is(err, skipped) {
+			message := message
+			return http.StatusFailedDependency, &message
+		}
+	}
+
+	status, respErr := errorResponse(logger, err)
+	return status, &respErr.Message
+}
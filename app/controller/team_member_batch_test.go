@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/service/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTeamMemberHandler_Batch(t *testing.T) {
+	const body = `{"mode":"atomic","operations":[
+		{"op":"create","data":{"name":"adam","username_github":"adamnasrudin03","email":"adam@example.com"}},
+		{"op":"delete","id":2}
+	]}`
+	tests := []struct {
+		name       string
+		body       string
+		authz      stubAuthorizer
+		mockFunc   func(srv *mocks.TeamMemberService)
+		wantStatus int
+		wantBody   []string
+	}{
+		{
+			name: "without route permission",
+			body: body,
+			authz: stubAuthorizer{
+				models.PermissionTeamMemberCreate: true,
+				models.PermissionTeamMemberDelete: true,
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "without operation permission",
+			body: body,
+			authz: stubAuthorizer{
+				models.PermissionTeamMemberRead:   true,
+				models.PermissionTeamMemberCreate: true,
+				models.PermissionTeamMemberUpdate: true,
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "without update permission when no operation updates",
+			body: body,
+			authz: stubAuthorizer{
+				models.PermissionTeamMemberRead:   true,
+				models.PermissionTeamMemberCreate: true,
+				models.PermissionTeamMemberDelete: true,
+			},
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Batch", mock.Anything, mock.Anything).Return(&dto.TeamMemberBatchRes{
+					Mode:      dto.BatchModeAtomic,
+					Committed: true,
+					Succeeded: 2,
+					Results: []dto.TeamMemberBatchResult{
+						{Index: 0, Op: dto.BatchOpCreate, ID: 7},
+						{Index: 1, Op: dto.BatchOpDelete, ID: 2},
+					},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid json",
+			body:       `{"operations":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown operation",
+			body:       `{"operations":[{"op":"upsert"}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "all succeeded",
+			body: body,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Batch", mock.Anything, mock.Anything).Return(&dto.TeamMemberBatchRes{
+					Mode:      dto.BatchModeAtomic,
+					Committed: true,
+					Succeeded: 2,
+					Results: []dto.TeamMemberBatchResult{
+						{Index: 0, Op: dto.BatchOpCreate, ID: 7},
+						{Index: 1, Op: dto.BatchOpDelete, ID: 2},
+					},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`"index":0,"op":"create","id":7,"status":201`, `"index":1,"op":"delete","id":2,"status":200`},
+		},
+		{
+			name: "rolled back",
+			body: body,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Batch", mock.Anything, mock.Anything).Return(&dto.TeamMemberBatchRes{
+					Mode:   dto.BatchModeAtomic,
+					Failed: 2,
+					Results: []dto.TeamMemberBatchResult{
+						{Index: 0, Op: dto.BatchOpCreate, Err: dto.ErrBatchRolledBack},
+						{Index: 1, Op: dto.BatchOpDelete, ID: 2, Err: response_mapper.ErrNotFound()},
+					},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusMultiStatus,
+			wantBody:   []string{`"committed":false`, `"status":424`, `"status":404`},
+		},
+		{
+			name: "failed batch",
+			body: body,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Batch", mock.Anything, mock.Anything).Return(nil, errors.New("invalid")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg    = &configs.Configs{}
+				srv    = &mocks.TeamMemberService{}
+				authz  = tt.authz
+				router = mux.NewRouter()
+			)
+			if authz == nil {
+				authz = adminPermissions
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc(srv)
+			}
+			NewTeamMemberDelivery(srv, cfg, driver.Logger(cfg), validator.New(), newTestGuard(authz)).
+				Mount(router.PathPrefix("/v1/team-members").Subrouter())
+
+			r := httptest.NewRequest(http.MethodPost, "/v1/team-members/batch", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("POST /v1/team-members/batch status = %v, want %v, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("POST /v1/team-members/batch body = %s, want it to contain %s", w.Body.String(), want)
+				}
+			}
+			srv.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamMemberHandler_BatchPermissionUnavailable(t *testing.T) {
+	var (
+		cfg    = &configs.Configs{}
+		srv    = &mocks.TeamMemberService{}
+		authz  = unavailableAuthorizer{models.PermissionTeamMemberRead: true}
+		guard  = middlewares.NewGuard(stubAuthenticator{caller: &models.Caller{ID: 1, Role: models.RoleAdmin}}, authz)
+		router = mux.NewRouter()
+	)
+	NewTeamMemberDelivery(srv, cfg, driver.Logger(cfg), validator.New(), guard).
+		Mount(router.PathPrefix("/v1/team-members").Subrouter())
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/team-members/batch", strings.NewReader(`{"operations":[{"op":"delete","id":2}]}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("POST /v1/team-members/batch status = %v, want %v, body %s", w.Code, http.StatusServiceUnavailable, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Errorf("POST /v1/team-members/batch Retry-After is missing")
+	}
+	srv.AssertExpectations(t)
+}
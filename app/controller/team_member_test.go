@@ -0,0 +1,391 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/service/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+)
+
+// stubAuthenticator authenticates every request as caller.
+type stubAuthenticator struct {
+	caller *models.Caller
+}
+
+func (a stubAuthenticator) Authenticate(r *http.Request) (*models.Caller, error) {
+	return a.caller, nil
+}
+
+// stubAuthorizer grants the permissions it holds to any role.
+type stubAuthorizer map[string]bool
+
+func (a stubAuthorizer) HasPermission(ctx context.Context, role string, permission string) (bool, error) {
+	return a[permission], nil
+}
+
+// unavailableAuthorizer grants the permissions it holds and fails to read the others.
+type unavailableAuthorizer map[string]bool
+
+func (a unavailableAuthorizer) HasPermission(ctx context.Context, role string, permission string) (bool, error) {
+	if !a[permission] {
+		return false, errors.New("connection refused")
+	}
+	return true, nil
+}
+
+var adminPermissions = func() stubAuthorizer {
+	authz := stubAuthorizer{}
+	for _, v := range models.DefaultRolePermissions[models.RoleAdmin] {
+		authz[v] = true
+	}
+	return authz
+}()
+
+func newTestGuard(authz stubAuthorizer) *middlewares.Guard {
+	return middlewares.NewGuard(stubAuthenticator{caller: &models.Caller{ID: 1, Role: models.RoleAdmin}}, authz)
+}
+
+func TestTeamMemberHandler_Routes(t *testing.T) {
+	var (
+		teamMember = &models.TeamMember{ID: 1, Name: "adam", UsernameGithub: "adamnasrudin03", Email: "adam@example.com"}
+		createReq  = dto.TeamMemberCreateReq{Name: "adam", UsernameGithub: "adamnasrudin03", Email: "adam@example.com"}
+		createBody = `{"name":"adam","username_github":"adamnasrudin03","email":"adam@example.com"}`
+		conflict   = response_mapper.NewError(response_mapper.ErrConflict, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{ID: "email sudah ada", EN: "email already exists"},
+		))
+	)
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		contentType  string
+		cacheControl string
+		body         string
+		authz        stubAuthorizer
+		mockFunc     func(srv *mocks.TeamMemberService)
+		wantStatus   int
+	}{
+		{
+			name:       "create without permission",
+			method:     http.MethodPost,
+			target:     "/v1/team-members",
+			body:       createBody,
+			authz:      stubAuthorizer{models.PermissionTeamMemberRead: true},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "create invalid json",
+			method:     http.MethodPost,
+			target:     "/v1/team-members",
+			body:       `{"name":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "create duplicate",
+			method: http.MethodPost,
+			target: "/v1/team-members",
+			body:   createBody,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Create", mock.Anything, createReq).Return(nil, conflict).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "create unique violation",
+			method: http.MethodPost,
+			target: "/v1/team-members",
+			body:   createBody,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Create", mock.Anything, createReq).Return(nil, &models.DBError{Kind: models.DBErrUniqueViolation, Field: "email"}).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "create failed db",
+			method: http.MethodPost,
+			target: "/v1/team-members",
+			body:   createBody,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Create", mock.Anything, createReq).Return(nil, response_mapper.ErrCreatedDB()).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "create success",
+			method: http.MethodPost,
+			target: "/v1/team-members",
+			body:   createBody,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Create", mock.Anything, createReq).Return(teamMember, nil).Once()
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "detail invalid id",
+			method:     http.MethodGet,
+			target:     "/v1/team-members/abc",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "detail not found",
+			method: http.MethodGet,
+			target: "/v1/team-members/1",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetByID", mock.Anything, uint64(1)).Return(nil, response_mapper.ErrNotFound()).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "detail unknown error",
+			method: http.MethodGet,
+			target: "/v1/team-members/1",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetByID", mock.Anything, uint64(1)).Return(nil, errors.New("invalid")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "detail success",
+			method: http.MethodGet,
+			target: "/v1/team-members/1",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetByID", mock.Anything, uint64(1)).Return(teamMember, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "delete not found",
+			method: http.MethodDelete,
+			target: "/v1/team-members/1",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("DeleteByID", mock.Anything, uint64(1), uint64(0)).Return(response_mapper.ErrNotFound()).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "delete success",
+			method: http.MethodDelete,
+			target: "/v1/team-members/1",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("DeleteByID", mock.Anything, uint64(1), uint64(0)).Return(nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "restore conflict",
+			method: http.MethodPost,
+			target: "/v1/team-members/1/restore",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("RestoreByID", mock.Anything, uint64(1)).Return(conflict).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "restore success",
+			method: http.MethodPost,
+			target: "/v1/team-members/1/restore",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("RestoreByID", mock.Anything, uint64(1)).Return(nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "update invalid json",
+			method:     http.MethodPut,
+			target:     "/v1/team-members/1",
+			body:       `[]`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "update serialization failure",
+			method: http.MethodPut,
+			target: "/v1/team-members/1",
+			body:   createBody,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Update", mock.Anything, mock.Anything).Return(nil, &models.DBError{Kind: models.DBErrSerializationFailure}).Once()
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "update success",
+			method: http.MethodPut,
+			target: "/v1/team-members/1",
+			body:   createBody,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Update", mock.Anything, dto.TeamMemberUpdateReq{
+					ID:             1,
+					Name:           createReq.Name,
+					UsernameGithub: createReq.UsernameGithub,
+					Email:          createReq.Email,
+				}).Return(&models.TeamMember{ID: 1, Version: 2}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "patch unsupported content type",
+			method:      http.MethodPatch,
+			target:      "/v1/team-members/1",
+			contentType: "text/plain",
+			body:        `name=adam`,
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "patch field that can not be patched",
+			method:      http.MethodPatch,
+			target:      "/v1/team-members/1",
+			contentType: "application/merge-patch+json",
+			body:        `{"id":2}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "patch failed test",
+			method:      http.MethodPatch,
+			target:      "/v1/team-members/1",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"test","path":"/name","value":"budi"},{"op":"replace","path":"/name","value":"adam"}]`,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Patch", mock.Anything, mock.Anything).Return(nil, conflict).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:        "patch success",
+			method:      http.MethodPatch,
+			target:      "/v1/team-members/1",
+			contentType: "application/merge-patch+json",
+			body:        `{"name":"adam"}`,
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Patch", mock.Anything, mock.MatchedBy(func(req dto.TeamMemberPatchReq) bool {
+					return req.ID == 1 && req.Patch != nil
+				})).Return(teamMember, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "list invalid filter",
+			method: http.MethodGet,
+			target: "/v1/team-members?order_by=up",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetList", mock.Anything, mock.Anything).Return(nil, response_mapper.ErrInvalidFormat("order_by", "order_by")).Once()
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "list without permission",
+			method: http.MethodGet,
+			target: "/v1/team-members",
+			authz:  stubAuthorizer{},
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetList", mock.Anything, mock.Anything).Return(&models.Pagination{}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "detail without permission",
+			method: http.MethodGet,
+			target: "/v1/team-members/1",
+			authz:  stubAuthorizer{},
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetByID", mock.Anything, uint64(1)).Return(teamMember, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "list deleted without permission",
+			method:     http.MethodGet,
+			target:     "/v1/team-members?include_deleted=true",
+			authz:      stubAuthorizer{models.PermissionTeamMemberRead: true},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "list success",
+			method: http.MethodGet,
+			target: "/v1/team-members?page=1&limit=10",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetList", mock.Anything, dto.TeamMemberListReq{
+					Page:  1,
+					Limit: 10,
+					Query: url.Values{"page": {"1"}, "limit": {"10"}},
+				}).Return(&models.Pagination{
+					Data: []models.TeamMember{*teamMember},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "list with filter",
+			method: http.MethodGet,
+			target: "/v1/team-members?filter[name][ilike]=adam&filter[created_at][gte]=2026-01-01",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetList", mock.Anything, dto.TeamMemberListReq{
+					Query: url.Values{"filter[name][ilike]": {"adam"}, "filter[created_at][gte]": {"2026-01-01"}},
+				}).Return(&models.Pagination{
+					Data: []models.TeamMember{*teamMember},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:         "list without cache",
+			method:       http.MethodGet,
+			target:       "/v1/team-members",
+			cacheControl: "max-age=0, No-Cache",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("GetList", mock.Anything, dto.TeamMemberListReq{
+					Query:   url.Values{},
+					NoCache: true,
+				}).Return(&models.Pagination{
+					Data: []models.TeamMember{*teamMember},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg    = &configs.Configs{}
+				srv    = &mocks.TeamMemberService{}
+				authz  = tt.authz
+				router = mux.NewRouter()
+			)
+			if authz == nil {
+				authz = adminPermissions
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc(srv)
+			}
+			NewTeamMemberDelivery(srv, cfg, driver.Logger(cfg), validator.New(), newTestGuard(authz)).
+				Mount(router.PathPrefix("/v1/team-members").Subrouter())
+
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.cacheControl != "" {
+				r.Header.Set("Cache-Control", tt.cacheControl)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("%v %v status = %v, want %v, body %s", tt.method, tt.target, w.Code, tt.wantStatus, w.Body.String())
+			}
+			srv.AssertExpectations(t)
+		})
+	}
+}
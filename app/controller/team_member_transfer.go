@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	help "github.com/adamnasrudin03/go-helpers"
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+)
+
+// transferTimeout replaces the server read and write timeouts for imports and exports,
+// which stream more rows than a request is expected to carry.
+const transferTimeout = 10 * time.Minute
+
+// transferContentTypes are the content types of the import and export formats.
+var transferContentTypes = map[string]string{
+	dto.FormatCSV:   "text/csv; charset=utf-8",
+	dto.FormatJSONL: "application/x-ndjson",
+}
+
+// transferFormats are the formats of the content types accepted by import.
+var transferFormats = map[string]string{
+	"text/csv":             dto.FormatCSV,
+	"application/csv":      dto.FormatCSV,
+	"application/x-ndjson": dto.FormatJSONL,
+	"application/jsonl":    dto.FormatJSONL,
+}
+
+// Import creates the team members of the CSV or JSON Lines body, read row by row.
+// The format is ?format= or read from the Content-Type, ?dry_run=true only checks the rows.
+func (c *TeamMemberHandler) Import(w http.ResponseWriter, r *http.Request) {
+	var (
+		opName = "TeamMemberController-Import"
+		query  = r.URL.Query()
+		format = query.Get("format")
+		err    error
+	)
+	extendDeadlines(w)
+
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		format = transferFormats[mediaType]
+	}
+	rows, err := dto.NewTeamMemberReader(r.Body, format)
+	if err != nil {
+		renderError(w, c.Logger, response_mapper.ErrInvalidFormat("format", "format"))
+		return
+	}
+
+	dryRun := false
+	if raw := query.Get("dry_run"); raw != "" {
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			renderError(w, c.Logger, response_mapper.ErrInvalidFormat("dry_run", "dry_run"))
+			return
+		}
+	}
+
+	res, err := c.Service.Import(r.Context(), rows, dryRun)
+	if err != nil {
+		c.Logger.Errorf("%v error: %v ", opName, err)
+		renderError(w, c.Logger, err)
+		return
+	}
+
+	response_mapper.RenderJSON(w, http.StatusOK, res)
+}
+
+// Export streams the team members of the list filtered like GetList as ?format=csv (the default) or jsonl.
+func (c *TeamMemberHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var (
+		opName  = "TeamMemberController-Export"
+		decoder = help.NewHttpDecoder()
+		input   dto.TeamMemberListReq
+		format  = r.URL.Query().Get("format")
+		err     error
+	)
+	extendDeadlines(w)
+
+	if format == "" {
+		format = dto.FormatCSV
+	}
+	contentType, ok := transferContentTypes[format]
+	if !ok {
+		renderError(w, c.Logger, response_mapper.ErrInvalidFormat("format", "format"))
+		return
+	}
+
+	err = decoder.Query(r, &input)
+	if err != nil {
+		c.Logger.Errorf("%v error bind json: %v ", opName, err)
+		renderError(w, c.Logger, response_mapper.ErrGetRequest())
+		return
+	}
+	input.Query = r.URL.Query()
+
+	// the status is only sent with the first row, errors before it are still rendered
+	out := &lazyHeaderWriter{ResponseWriter: w, header: func(h http.Header) {
+		h.Set("Content-Type", contentType)
+		h.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="team-members.%s"`, format))
+	}}
+	writer, _ := dto.NewTeamMemberWriter(out, format)
+	err = c.Service.Export(r.Context(), input, writer.Write)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		c.Logger.Errorf("%v error: %v ", opName, err)
+		if !out.isWritten {
+			renderError(w, c.Logger, err)
+		}
+		return
+	}
+
+	// an empty JSON Lines export writes nothing
+	if !out.isWritten {
+		out.header(w.Header())
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// lazyHeaderWriter sets the response headers with header on the first write.
+type lazyHeaderWriter struct {
+	http.ResponseWriter
+	header    func(h http.Header)
+	isWritten bool
+}
+
+func (w *lazyHeaderWriter) Write(p []byte) (int, error) {
+	if !w.isWritten {
+		w.isWritten = true
+		w.header(w.ResponseWriter.Header())
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// extendDeadlines gives the request transferTimeout to be read and answered.
+func extendDeadlines(w http.ResponseWriter) {
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(transferTimeout)
+	// writers that do not support deadlines have no timeout to extend
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/service/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTeamMemberHandler_Transfer(t *testing.T) {
+	var (
+		teamMember = models.TeamMember{ID: 1, Name: "adam", UsernameGithub: "adamnasrudin03", Email: "adam@example.com"}
+		report     = &dto.TeamMemberImportReport{DryRun: true, Total: 1, Valid: 1, Rows: []dto.TeamMemberImportRow{{Row: 1, Status: dto.ImportStatusValid}}}
+		exportRows = func(members ...models.TeamMember) func(args mock.Arguments) {
+			return func(args mock.Arguments) {
+				fn := args.Get(2).(func(member models.TeamMember) error)
+				for _, member := range members {
+					_ = fn(member)
+				}
+			}
+		}
+	)
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		contentType     string
+		body            string
+		authz           stubAuthorizer
+		mockFunc        func(srv *mocks.TeamMemberService)
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:       "import without permission",
+			method:     http.MethodPost,
+			target:     "/v1/team-members/import?format=csv",
+			authz:      stubAuthorizer{models.PermissionTeamMemberRead: true},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "import unknown format",
+			method:      http.MethodPost,
+			target:      "/v1/team-members/import",
+			contentType: "application/xml",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "import invalid dry run",
+			method:     http.MethodPost,
+			target:     "/v1/team-members/import?format=csv&dry_run=maybe",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "import dry run",
+			method:      http.MethodPost,
+			target:      "/v1/team-members/import?dry_run=true",
+			contentType: "text/csv; charset=utf-8",
+			body:        "name,username_github,email\nadam,adamnasrudin03,adam@example.com\n",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Import", mock.Anything, mock.Anything, true).Return(report, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"status":"valid"`,
+		},
+		{
+			name:   "import failed read",
+			method: http.MethodPost,
+			target: "/v1/team-members/import?format=jsonl",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Import", mock.Anything, mock.Anything, false).Return(nil, errors.New("unexpected EOF")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "export unknown format",
+			method:     http.MethodGet,
+			target:     "/v1/team-members/export?format=xml",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "export deleted without permission",
+			method:     http.MethodGet,
+			target:     "/v1/team-members/export?include_deleted=true",
+			authz:      stubAuthorizer{models.PermissionTeamMemberRead: true},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "export csv",
+			method: http.MethodGet,
+			target: "/v1/team-members/export?search=adam",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Export", mock.Anything, mock.MatchedBy(func(req dto.TeamMemberListReq) bool {
+					return req.Search == "adam"
+				}), mock.Anything).Run(exportRows(teamMember)).Return(nil).Once()
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody:        "1,adam,adamnasrudin03,adam@example.com,",
+		},
+		{
+			name:   "export empty jsonl",
+			method: http.MethodGet,
+			target: "/v1/team-members/export?format=jsonl",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Export", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+		},
+		{
+			name:   "export failed before the first row",
+			method: http.MethodGet,
+			target: "/v1/team-members/export?format=jsonl",
+			mockFunc: func(srv *mocks.TeamMemberService) {
+				srv.On("Export", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("invalid")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg    = &configs.Configs{}
+				srv    = &mocks.TeamMemberService{}
+				authz  = tt.authz
+				router = mux.NewRouter()
+			)
+			if authz == nil {
+				authz = adminPermissions
+			}
+			if tt.mockFunc != nil {
+				tt.mockFunc(srv)
+			}
+			NewTeamMemberDelivery(srv, cfg, driver.Logger(cfg), validator.New(), newTestGuard(authz)).
+				Mount(router.PathPrefix("/v1/team-members").Subrouter())
+
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("%v %v status = %v, want %v, body %s", tt.method, tt.target, w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantContentType != "" && w.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("%v %v Content-Type = %v, want %v", tt.method, tt.target, w.Header().Get("Content-Type"), tt.wantContentType)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("%v %v body = %s, want it to contain %s", tt.method, tt.target, w.Body.String(), tt.wantBody)
+			}
+			srv.AssertExpectations(t)
+		})
+	}
+}
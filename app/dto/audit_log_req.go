@@ -0,0 +1,40 @@
+package dto
+
+import (
+	help "github.com/adamnasrudin03/go-helpers"
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+type AuditLogListReq struct {
+	models.BasedFilter
+	Entity   string `json:"entity"`
+	EntityID uint64 `json:"entity_id"`
+	ActorID  uint64 `json:"actor_id"`
+	Action   string `json:"action"`
+}
+
+func (m *AuditLogListReq) Validate() error {
+	m.DefaultQuery()
+
+	m.Entity = help.ToLower(m.Entity)
+	m.Action = help.ToLower(m.Action)
+
+	m.OrderBy = help.ToUpper(m.OrderBy)
+	if !models.IsValidOrderBy[m.OrderBy] && m.OrderBy != "" {
+		return response_mapper.ErrInvalidFormat("order_by", "order_by")
+	}
+
+	m.SortBy = help.ToLower(m.SortBy)
+	if m.OrderBy != "" && m.SortBy == "" {
+		return response_mapper.ErrIsRequired("sort_by", "sort_by")
+	}
+
+	var err error
+	m.Sorts, m.Selects, err = parseList(models.AuditLogFields, m.Sort, m.SortBy, m.OrderBy, m.Fields)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
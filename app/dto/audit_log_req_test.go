@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+func TestAuditLogListReq_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *AuditLogListReq
+		want    models.BasedFilter
+		wantErr bool
+	}{
+		{
+			name: "invalid order by",
+			m: &AuditLogListReq{
+				BasedFilter: models.BasedFilter{OrderBy: "invalid"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sort by not allowed",
+			m: &AuditLogListReq{
+				BasedFilter: models.BasedFilter{OrderBy: models.OrderByASC, SortBy: "changes"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sort by required if order by provided",
+			m: &AuditLogListReq{
+				BasedFilter: models.BasedFilter{OrderBy: models.OrderByASC},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success with default query",
+			m: &AuditLogListReq{
+				BasedFilter: models.BasedFilter{Page: 3, OrderBy: "desc", SortBy: "CREATED_AT"},
+			},
+			want: models.BasedFilter{
+				Limit:   10,
+				Offset:  20,
+				Page:    3,
+				OrderBy: models.OrderByDESC,
+				SortBy:  "created_at",
+				Sorts:   []models.Sort{{Column: "created_at", Desc: true}},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuditLogListReq.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.m.BasedFilter, tt.want) {
+				t.Errorf("AuditLogListReq.Validate() = %+v, want %+v", tt.m.BasedFilter, tt.want)
+			}
+		})
+	}
+}
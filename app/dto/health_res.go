@@ -0,0 +1,32 @@
+package dto
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+type HealthComponentRes struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type HealthRes struct {
+	Status     string                        `json:"status"`
+	Components map[string]HealthComponentRes `json:"components,omitempty"`
+}
+
+// IsUp reports whether the overall status and every component are up.
+func (m *HealthRes) IsUp() bool {
+	if m.Status != HealthStatusUp {
+		return false
+	}
+
+	for _, v := range m.Components {
+		if v.Status != HealthStatusUp {
+			return false
+		}
+	}
+
+	return true
+}
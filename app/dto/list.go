@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// parseList checks sort and fields against the registry of the listed model,
+// sort_by with order_by stand for a sort on one field when sort is empty.
+func parseList(fields models.Fields, sort, sortBy, orderBy, selects string) ([]models.Sort, []string, error) {
+	if sort == "" && sortBy != "" {
+		sort = sortBy
+		if orderBy == models.OrderByDESC {
+			sort = "-" + sortBy
+		}
+	}
+
+	sorts, err := fields.ParseSort(sort)
+	if err != nil {
+		return nil, nil, errField(err)
+	}
+
+	names, err := fields.ParseSelect(selects)
+	if err != nil {
+		return nil, nil, errField(err)
+	}
+
+	return sorts, names, nil
+}
+
+func errField(err error) error {
+	var fieldErr *models.FieldError
+	if !errors.As(err, &fieldErr) {
+		return err
+	}
+
+	return response_mapper.NewError(response_mapper.ErrValidation, response_mapper.NewResponseMultiLang(
+		response_mapper.MultiLanguages{
+			ID: fmt.Sprintf("field %s tidak valid pada %s", fieldErr.Name, fieldErr.Param),
+			EN: fmt.Sprintf("invalid field %s in %s", fieldErr.Name, fieldErr.Param),
+		},
+	))
+}
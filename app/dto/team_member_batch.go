@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+)
+
+const (
+	// BatchModeAtomic commits every operation or none of them.
+	BatchModeAtomic = "atomic"
+	// BatchModeBestEffort commits the operations that succeed and reports the others.
+	BatchModeBestEffort = "best_effort"
+
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+
+	// BatchMaxOperations is the most operations a batch may carry.
+	BatchMaxOperations = 100
+)
+
+var (
+	// ErrBatchRolledBack is the error of an atomic batch operation that succeeded
+	// but was rolled back because another one failed.
+	ErrBatchRolledBack = errors.New("rolled back")
+	// ErrBatchNotRun is the error of an atomic batch operation that was not run
+	// because an earlier one failed.
+	ErrBatchNotRun = errors.New("not run")
+)
+
+// TeamMemberBatchOperation is one operation of a batch, ID is required by update and delete
+// and Data by create and update. Version is the version update and delete expect, any when 0.
+type TeamMemberBatchOperation struct {
+	Op      string              `json:"op"`
+	ID      uint64              `json:"id,omitempty"`
+	Version uint64              `json:"version,omitempty"`
+	Data    TeamMemberCreateReq `json:"data"`
+}
+
+type TeamMemberBatchReq struct {
+	Mode       string                     `json:"mode"`
+	Operations []TeamMemberBatchOperation `json:"operations"`
+}
+
+// Validate checks the envelope of the batch, the data of each operation is checked
+// when it is run so that one invalid operation does not reject the others.
+func (m *TeamMemberBatchReq) Validate() error {
+	if m.Mode == "" {
+		m.Mode = BatchModeAtomic
+	}
+	if m.Mode != BatchModeAtomic && m.Mode != BatchModeBestEffort {
+		return response_mapper.ErrInvalidFormat("mode", "mode")
+	}
+
+	if len(m.Operations) == 0 {
+		return response_mapper.ErrIsRequired("operations", "operations")
+	}
+	if len(m.Operations) > BatchMaxOperations {
+		return response_mapper.NewError(response_mapper.ErrValidation, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: fmt.Sprintf("operations maksimal %d", BatchMaxOperations),
+				EN: fmt.Sprintf("operations must be at most %d", BatchMaxOperations),
+			},
+		))
+	}
+
+	for i, op := range m.Operations {
+		field := fmt.Sprintf("operations[%d]", i)
+		switch op.Op {
+		case BatchOpCreate:
+		case BatchOpUpdate, BatchOpDelete:
+			if op.ID == 0 {
+				return response_mapper.ErrIsRequired(field+".id", field+".id")
+			}
+		default:
+			return response_mapper.ErrInvalidFormat(field+".op", field+".op")
+		}
+	}
+
+	return nil
+}
+
+// TeamMemberBatchResult is the outcome of the operation at Index, Err is mapped to
+// Status and Error by the controller.
+type TeamMemberBatchResult struct {
+	Index  int                             `json:"index"`
+	Op     string                          `json:"op"`
+	ID     uint64                          `json:"id,omitempty"`
+	Status int                             `json:"status"`
+	Error  *response_mapper.MultiLanguages `json:"error,omitempty"`
+	Err    error                           `json:"-"`
+}
+
+// TeamMemberBatchRes sums up a batch, Committed is false when nothing was written.
+type TeamMemberBatchRes struct {
+	Mode      string                  `json:"mode"`
+	Committed bool                    `json:"committed"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Results   []TeamMemberBatchResult `json:"results"`
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"testing"
+)
+
+func TestTeamMemberBatchReq_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        *TeamMemberBatchReq
+		wantMode string
+		wantErr  bool
+	}{
+		{
+			name:    "invalid mode",
+			m:       &TeamMemberBatchReq{Mode: "sometimes", Operations: []TeamMemberBatchOperation{{Op: BatchOpCreate}}},
+			wantErr: true,
+		},
+		{
+			name:    "operations required",
+			m:       &TeamMemberBatchReq{},
+			wantErr: true,
+		},
+		{
+			name:    "too many operations",
+			m:       &TeamMemberBatchReq{Operations: make([]TeamMemberBatchOperation, BatchMaxOperations+1)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown operation",
+			m:       &TeamMemberBatchReq{Operations: []TeamMemberBatchOperation{{Op: "upsert"}}},
+			wantErr: true,
+		},
+		{
+			name:    "id required",
+			m:       &TeamMemberBatchReq{Operations: []TeamMemberBatchOperation{{Op: BatchOpCreate}, {Op: BatchOpDelete}}},
+			wantErr: true,
+		},
+		{
+			name: "atomic by default",
+			m: &TeamMemberBatchReq{Operations: []TeamMemberBatchOperation{
+				{Op: BatchOpCreate},
+				{Op: BatchOpUpdate, ID: 1},
+				{Op: BatchOpDelete, ID: 2},
+			}},
+			wantMode: BatchModeAtomic,
+		},
+		{
+			name:     "best effort",
+			m:        &TeamMemberBatchReq{Mode: BatchModeBestEffort, Operations: []TeamMemberBatchOperation{{Op: BatchOpCreate}}},
+			wantMode: BatchModeBestEffort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberBatchReq.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && tt.m.Mode != tt.wantMode {
+				t.Errorf("TeamMemberBatchReq.Validate() mode = %v, want %v", tt.m.Mode, tt.wantMode)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	help "github.com/adamnasrudin03/go-helpers"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+const (
+	ContentTypeMergePatch = "application/merge-patch+json"
+	ContentTypeJSONPatch  = "application/json-patch+json"
+)
+
+var (
+	ErrUnsupportedPatch = fmt.Errorf("content type must be %s or %s", ContentTypeMergePatch, ContentTypeJSONPatch)
+	// ErrInvalidPatch is wrapped by the errors of a patch that can not be decoded or applied.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPatchTestFailed is returned when a JSON Patch test operation does not match the team member.
+	ErrPatchTestFailed = errors.New("patch test failed")
+
+	// TeamMemberPatchFields maps the columns a patch may change to their TeamMemberUpdateReq field.
+	TeamMemberPatchFields = map[string]string{
+		"name":            "Name",
+		"username_github": "UsernameGithub",
+		"email":           "Email",
+	}
+	teamMemberPatchColumns = []string{"name", "username_github", "email"}
+)
+
+// TeamMemberPatch changes a team member document, the JSON object of its patchable columns.
+type TeamMemberPatch interface {
+	Apply(doc map[string]interface{}) error
+}
+
+type TeamMemberPatchReq struct {
+	ID    uint64
+	Patch TeamMemberPatch
+	// Version is the version the team member must be at, any when 0.
+	Version uint64
+}
+
+// NewTeamMemberPatch decodes body as a JSON Merge Patch (RFC 7396), the default for
+// application/json, or as a JSON Patch (RFC 6902) for application/json-patch+json.
+func NewTeamMemberPatch(mediaType string, body []byte) (TeamMemberPatch, error) {
+	switch mediaType {
+	case ContentTypeMergePatch, "application/json", "":
+		var patch mergePatch
+		if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+			return nil, fmt.Errorf("%w: body must be a JSON object", ErrInvalidPatch)
+		}
+		for column := range patch {
+			if err := checkPatchColumn(column); err != nil {
+				return nil, err
+			}
+		}
+		return patch, nil
+	case ContentTypeJSONPatch:
+		var patch jsonPatch
+		if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+			return nil, fmt.Errorf("%w: body must be a JSON array of operations", ErrInvalidPatch)
+		}
+		for i := range patch {
+			if err := patch[i].decode(); err != nil {
+				return nil, fmt.Errorf("operation %d: %w", i, err)
+			}
+		}
+		return patch, nil
+	default:
+		return nil, ErrUnsupportedPatch
+	}
+}
+
+// Apply patches the columns of member, returning the patched team member and the columns
+// whose value changed, in a fixed order. Email and username_github are lower cased like on create.
+func (m TeamMemberPatchReq) Apply(member models.TeamMember) (TeamMemberUpdateReq, []string, error) {
+	doc := map[string]interface{}{
+		"name":            member.Name,
+		"username_github": member.UsernameGithub,
+		"email":           member.Email,
+	}
+	err := m.Patch.Apply(doc)
+	if err != nil {
+		return TeamMemberUpdateReq{}, nil, err
+	}
+
+	values := map[string]string{}
+	for column, value := range doc {
+		str, ok := value.(string)
+		if !ok {
+			return TeamMemberUpdateReq{}, nil, fmt.Errorf("%w: %s must be a string", ErrInvalidPatch, column)
+		}
+		values[column] = str
+	}
+
+	req := TeamMemberUpdateReq{
+		ID:             member.ID,
+		Version:        m.Version,
+		Name:           values["name"],
+		UsernameGithub: help.ToLower(values["username_github"]),
+		Email:          help.ToLower(values["email"]),
+	}
+	before := map[string]string{"name": member.Name, "username_github": member.UsernameGithub, "email": member.Email}
+	after := map[string]string{"name": req.Name, "username_github": req.UsernameGithub, "email": req.Email}
+
+	var columns []string
+	for _, column := range teamMemberPatchColumns {
+		if before[column] != after[column] {
+			columns = append(columns, column)
+		}
+	}
+
+	return req, columns, nil
+}
+
+func checkPatchColumn(column string) error {
+	if _, ok := TeamMemberPatchFields[column]; !ok {
+		return fmt.Errorf("%w: %s can not be patched", ErrInvalidPatch, column)
+	}
+	return nil
+}
+
+// mergePatch sets the columns it holds and removes those set to null,
+// the columns of a team member are not objects so there is nothing to merge deeper.
+type mergePatch map[string]json.RawMessage
+
+func (p mergePatch) Apply(doc map[string]interface{}) error {
+	for column, raw := range p {
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			delete(doc, column)
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		doc[column] = value
+	}
+
+	return nil
+}
+
+type jsonPatch []jsonPatchOperation
+
+// jsonPatchOperation is one operation of a JSON Patch, its paths point to a column.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+
+	column, from string
+	value        interface{}
+}
+
+func (o *jsonPatchOperation) decode() error {
+	var err error
+	o.column, err = patchPathColumn(o.Path)
+	if err != nil {
+		return err
+	}
+
+	switch o.Op {
+	case "add", "replace", "test":
+		if o.Value == nil {
+			return fmt.Errorf("%w: value is required", ErrInvalidPatch)
+		}
+		if err := json.Unmarshal(o.Value, &o.value); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+	case "move", "copy":
+		o.from, err = patchPathColumn(o.From)
+		if err != nil {
+			return err
+		}
+	case "remove":
+	default:
+		return fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, o.Op)
+	}
+
+	return nil
+}
+
+func (p jsonPatch) Apply(doc map[string]interface{}) error {
+	for i, o := range p {
+		current, exists := doc[o.column]
+		if !exists && (o.Op == "remove" || o.Op == "replace" || o.Op == "test") {
+			return fmt.Errorf("%w: operation %d: path %s does not exist", ErrInvalidPatch, i, o.Path)
+		}
+		if _, ok := doc[o.from]; !ok && (o.Op == "move" || o.Op == "copy") {
+			return fmt.Errorf("%w: operation %d: from %s does not exist", ErrInvalidPatch, i, o.From)
+		}
+
+		switch o.Op {
+		case "add", "replace":
+			doc[o.column] = o.value
+		case "remove":
+			delete(doc, o.column)
+		case "move":
+			value := doc[o.from]
+			delete(doc, o.from)
+			doc[o.column] = value
+		case "copy":
+			doc[o.column] = doc[o.from]
+		case "test":
+			if !reflect.DeepEqual(current, o.value) {
+				return fmt.Errorf("%w: operation %d: %s", ErrPatchTestFailed, i, o.Path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// patchPathColumn returns the column a JSON Pointer (RFC 6901) points to.
+func patchPathColumn(path string) (string, error) {
+	if !strings.HasPrefix(path, "/") || strings.Count(path, "/") != 1 {
+		return "", fmt.Errorf("%w: path %q must point to a column", ErrInvalidPatch, path)
+	}
+
+	column := strings.NewReplacer("~1", "/", "~0", "~").Replace(path[1:])
+	return column, checkPatchColumn(column)
+}
@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+func TestTeamMemberPatchReq_Apply(t *testing.T) {
+	member := models.TeamMember{ID: 1, Name: "adam", UsernameGithub: "adamnasrudin03", Email: "adam@example.com"}
+	tests := []struct {
+		name        string
+		mediaType   string
+		body        string
+		want        TeamMemberUpdateReq
+		wantColumns []string
+		wantErr     error
+	}{
+		{
+			name:      "unsupported content type",
+			mediaType: "text/plain",
+			body:      `{}`,
+			wantErr:   ErrUnsupportedPatch,
+		},
+		{
+			name:      "merge patch not an object",
+			mediaType: ContentTypeMergePatch,
+			body:      `["name"]`,
+			wantErr:   ErrInvalidPatch,
+		},
+		{
+			name:      "merge patch field that can not be patched",
+			mediaType: ContentTypeMergePatch,
+			body:      `{"id":2}`,
+			wantErr:   ErrInvalidPatch,
+		},
+		{
+			name:      "merge patch value not a string",
+			mediaType: ContentTypeMergePatch,
+			body:      `{"name":{"first":"adam"}}`,
+			wantErr:   ErrInvalidPatch,
+		},
+		{
+			name:        "merge patch sets and clears",
+			mediaType:   ContentTypeMergePatch,
+			body:        `{"email":"ADAM@example.org","name":null}`,
+			want:        TeamMemberUpdateReq{ID: 1, UsernameGithub: "adamnasrudin03", Email: "adam@example.org"},
+			wantColumns: []string{"name", "email"},
+		},
+		{
+			name:        "merge patch of application/json without changes",
+			mediaType:   "application/json",
+			body:        `{"username_github":"AdamNasrudin03"}`,
+			want:        TeamMemberUpdateReq{ID: 1, Name: "adam", UsernameGithub: "adamnasrudin03", Email: "adam@example.com"},
+			wantColumns: nil,
+		},
+		{
+			name:      "json patch unknown op",
+			mediaType: ContentTypeJSONPatch,
+			body:      `[{"op":"merge","path":"/name","value":"budi"}]`,
+			wantErr:   ErrInvalidPatch,
+		},
+		{
+			name:      "json patch nested path",
+			mediaType: ContentTypeJSONPatch,
+			body:      `[{"op":"replace","path":"/name/first","value":"budi"}]`,
+			wantErr:   ErrInvalidPatch,
+		},
+		{
+			name:      "json patch value required",
+			mediaType: ContentTypeJSONPatch,
+			body:      `[{"op":"replace","path":"/name"}]`,
+			wantErr:   ErrInvalidPatch,
+		},
+		{
+			name:      "json patch failed test",
+			mediaType: ContentTypeJSONPatch,
+			body:      `[{"op":"test","path":"/name","value":"budi"},{"op":"replace","path":"/name","value":"budi"}]`,
+			wantErr:   ErrPatchTestFailed,
+		},
+		{
+			name:      "json patch remove missing path",
+			mediaType: ContentTypeJSONPatch,
+			body:      `[{"op":"remove","path":"/name"},{"op":"remove","path":"/name"}]`,
+			wantErr:   ErrInvalidPatch,
+		},
+		{
+			name:      "json patch",
+			mediaType: ContentTypeJSONPatch,
+			body: `[
+				{"op":"test","path":"/name","value":"adam"},
+				{"op":"copy","from":"/name","path":"/username_github"},
+				{"op":"replace","path":"/name","value":"Adam Nasrudin"}
+			]`,
+			want:        TeamMemberUpdateReq{ID: 1, Name: "Adam Nasrudin", UsernameGithub: "adam", Email: "adam@example.com"},
+			wantColumns: []string{"name", "username_github"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := NewTeamMemberPatch(tt.mediaType, []byte(tt.body))
+			var (
+				got     TeamMemberUpdateReq
+				columns []string
+			)
+			if err == nil {
+				got, columns, err = TeamMemberPatchReq{ID: member.ID, Patch: patch}.Apply(member)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TeamMemberPatchReq.Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("TeamMemberPatchReq.Apply() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("TeamMemberPatchReq.Apply() columns = %v, want %v", columns, tt.wantColumns)
+			}
+		})
+	}
+}
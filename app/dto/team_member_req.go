@@ -1,6 +1,11 @@
 package dto
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+
 	help "github.com/adamnasrudin03/go-helpers"
 	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
@@ -13,6 +18,9 @@ type TeamMemberDetailReq struct {
 	Email          string `json:"email"`
 	CustomColumn   string `json:"custom_column"`
 	NotID          uint64 `json:"not_id"`
+	IncludeDeleted bool   `json:"include_deleted"`
+	// ForUpdate locks the row until the end of the transaction the lookup runs in.
+	ForUpdate bool `json:"for_update"`
 }
 
 type TeamMemberCreateReq struct {
@@ -26,6 +34,8 @@ type TeamMemberUpdateReq struct {
 	Name           string `json:"name" validate:"required"`
 	UsernameGithub string `json:"username_github" validate:"required"`
 	Email          string `json:"email" validate:"required,email"`
+	// Version is the version the team member must be at, any when 0.
+	Version uint64 `json:"-"`
 }
 
 type TeamMemberListReq struct {
@@ -37,7 +47,24 @@ type TeamMemberListReq struct {
 	SortBy            string `json:"sort_by"`
 	IsNoLimit         bool   `json:"is_no_limit"`
 	IsNotDefaultQuery bool   `json:"is_not_default_query"`
-	CustomColumns     string `json:"custom_columns"`
+	IncludeDeleted    bool   `json:"include_deleted"`
+	Cursor            string `json:"cursor"`
+	Sort              string `json:"sort"`
+	Fields            string `json:"fields"`
+
+	// Query is the raw query string, its filter[field][operator] parameters are read into Filter.
+	Query url.Values `json:"-"`
+
+	// Sorts, Selects and Filter are Sort, Fields and Query checked against models.TeamMemberFields.
+	Sorts   []models.Sort `json:"-"`
+	Selects []string      `json:"-"`
+	Filter  models.Filter `json:"-"`
+
+	// Keyset is the decoded Cursor, the list is read after it instead of by offset.
+	Keyset *models.Cursor `json:"-"`
+
+	// NoCache reads the list from the database instead of the cache, as asked by Cache-Control: no-cache.
+	NoCache bool `json:"-"`
 }
 
 func (m *TeamMemberListReq) Validate() error {
@@ -61,9 +88,53 @@ func (m *TeamMemberListReq) Validate() error {
 		return response_mapper.ErrIsRequired("sort_by", "sort_by")
 	}
 
+	var err error
+	m.Sorts, m.Selects, err = parseList(models.TeamMemberFields, m.Sort, m.SortBy, m.OrderBy, m.Fields)
+	if err != nil {
+		return err
+	}
+
+	m.Filter, err = models.TeamMemberFields.ParseFilter(m.Query)
+	if err != nil {
+		return errField(err)
+	}
+
 	return nil
 }
 
+// Hash identifies the page read for the validated request, requests spelled differently
+// (search case, sort_by or sort, default page and limit, parameter order) share it.
+func (m TeamMemberListReq) Hash() string {
+	payload, _ := json.Marshal(struct {
+		Search            string        `json:"search"`
+		Page              int           `json:"page"`
+		Limit             int           `json:"limit"`
+		Offset            int           `json:"offset"`
+		IsNoLimit         bool          `json:"is_no_limit"`
+		IsNotDefaultQuery bool          `json:"is_not_default_query"`
+		IncludeDeleted    bool          `json:"include_deleted"`
+		Cursor            string        `json:"cursor"`
+		Sorts             []models.Sort `json:"sorts"`
+		Selects           []string      `json:"selects"`
+		Filter            models.Filter `json:"filter"`
+	}{
+		Search:            m.Search,
+		Page:              m.Page,
+		Limit:             m.Limit,
+		Offset:            m.Offset,
+		IsNoLimit:         m.IsNoLimit,
+		IsNotDefaultQuery: m.IsNotDefaultQuery,
+		IncludeDeleted:    m.IncludeDeleted,
+		Cursor:            m.Cursor,
+		Sorts:             m.Sorts,
+		Selects:           m.Selects,
+		Filter:            m.Filter,
+	})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
 func (c *TeamMemberListReq) DefaultQuery() TeamMemberListReq {
 	if c.Limit <= 0 {
 		c.Limit = 10
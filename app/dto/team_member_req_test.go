@@ -1,7 +1,9 @@
 package dto
 
 import (
+	"net/url"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
@@ -29,6 +31,20 @@ func TestTeamMemberListReq_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid filter",
+			m: &TeamMemberListReq{
+				Query: url.Values{"filter[password]": {"secret"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success with filter",
+			m: &TeamMemberListReq{
+				Query: url.Values{"filter[name][ilike]": {"adam"}},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "success",
 			m:       &TeamMemberListReq{},
@@ -44,6 +60,62 @@ func TestTeamMemberListReq_Validate(t *testing.T) {
 	}
 }
 
+func TestTeamMemberListReq_Validate_sortAndFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		m           *TeamMemberListReq
+		wantSorts   []models.Sort
+		wantSelects []string
+		wantErr     bool
+	}{
+		{
+			name:      "sort_by and order_by as one sort",
+			m:         &TeamMemberListReq{SortBy: "Name", OrderBy: "desc"},
+			wantSorts: []models.Sort{{Column: "name", Desc: true}},
+		},
+		{
+			name:      "sort takes precedence over sort_by",
+			m:         &TeamMemberListReq{Sort: "-created_at,name", SortBy: "email"},
+			wantSorts: []models.Sort{{Column: "created_at", Desc: true}, {Column: "name"}},
+		},
+		{
+			name:    "sort_by not allowed",
+			m:       &TeamMemberListReq{SortBy: "name; drop table team_members", OrderBy: models.OrderByASC},
+			wantErr: true,
+		},
+		{
+			name:    "sort not allowed",
+			m:       &TeamMemberListReq{Sort: "password"},
+			wantErr: true,
+		},
+		{
+			name:        "fields",
+			m:           &TeamMemberListReq{Fields: "name,email"},
+			wantSelects: []string{"id", "name", "email"},
+		},
+		{
+			name:    "fields not allowed",
+			m:       &TeamMemberListReq{Fields: "name,(select 1)"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberListReq.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(tt.m.Sorts, tt.wantSorts) {
+				t.Errorf("TeamMemberListReq.Validate() sorts = %v, want %v", tt.m.Sorts, tt.wantSorts)
+			}
+			if !reflect.DeepEqual(tt.m.Selects, tt.wantSelects) {
+				t.Errorf("TeamMemberListReq.Validate() selects = %v, want %v", tt.m.Selects, tt.wantSelects)
+			}
+		})
+	}
+}
+
 func TestTeamMemberListReq_DefaultQuery(t *testing.T) {
 	tests := []struct {
 		name string
@@ -68,3 +140,53 @@ func TestTeamMemberListReq_DefaultQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamMemberListReq_Hash(t *testing.T) {
+	validated := func(query string) TeamMemberListReq {
+		values, _ := url.ParseQuery(query)
+		m := TeamMemberListReq{
+			Search:  values.Get("search"),
+			Sort:    values.Get("sort"),
+			SortBy:  values.Get("sort_by"),
+			OrderBy: values.Get("order_by"),
+			Query:   values,
+		}
+		if values.Has("page") {
+			m.Page, _ = strconv.Atoi(values.Get("page"))
+		}
+		if err := m.Validate(); err != nil {
+			t.Fatalf("TeamMemberListReq.Validate() error = %v", err)
+		}
+		return m
+	}
+	tests := []struct {
+		name     string
+		a, b     string
+		wantSame bool
+	}{
+		{
+			name:     "spelled differently",
+			a:        "search=Adam&sort=-name&filter[email][ilike]=example&filter[id][gt]=1",
+			b:        "filter[id][gt]=1&search=adam&sort_by=name&order_by=desc&page=1&filter[email][ilike]=example",
+			wantSame: true,
+		},
+		{
+			name: "another page",
+			a:    "search=adam",
+			b:    "search=adam&page=2",
+		},
+		{
+			name: "another filter",
+			a:    "filter[id][gt]=1",
+			b:    "filter[id][gt]=2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := validated(tt.a).Hash(), validated(tt.b).Hash()
+			if (a == b) != tt.wantSame {
+				t.Errorf("TeamMemberListReq.Hash() of %q = %v and of %q = %v, wantSame %v", tt.a, a, tt.b, b, tt.wantSame)
+			}
+		})
+	}
+}
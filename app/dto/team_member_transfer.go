@@ -0,0 +1,253 @@
+package dto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+
+	ImportStatusCreated = "created"
+	ImportStatusValid   = "valid"
+	ImportStatusFailed  = "failed"
+
+	// ImportMaxReportedRows bounds TeamMemberImportReport.Rows, the counts cover every row.
+	ImportMaxReportedRows = 1000
+)
+
+var (
+	ErrUnknownFormat = errors.New("format must be csv or jsonl")
+	// ErrInvalidRow wraps the failure to decode one row, the rows after it can still be read.
+	ErrInvalidRow = errors.New("invalid row")
+
+	teamMemberImportColumns = []string{"name", "username_github", "email"}
+	teamMemberExportColumns = []string{"id", "name", "username_github", "email", "created_at", "updated_at", "deleted_at"}
+)
+
+// TeamMemberImportRow is the outcome of one imported row, Row counts data rows from 1.
+type TeamMemberImportRow struct {
+	Row    int    `json:"row"`
+	Status string `json:"status"`
+	ID     uint64 `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// TeamMemberImportReport sums up an import, rows are only validated when DryRun is set.
+// Rows holds the outcome of the first ImportMaxReportedRows rows, Truncated is set when
+// the file had more.
+type TeamMemberImportReport struct {
+	DryRun    bool                  `json:"dry_run"`
+	Total     int                   `json:"total"`
+	Created   int                   `json:"created"`
+	Valid     int                   `json:"valid"`
+	Failed    int                   `json:"failed"`
+	Truncated bool                  `json:"truncated"`
+	Rows      []TeamMemberImportRow `json:"rows"`
+}
+
+// Add counts row and reports it while fewer than ImportMaxReportedRows rows are.
+func (r *TeamMemberImportReport) Add(row TeamMemberImportRow) {
+	switch row.Status {
+	case ImportStatusCreated:
+		r.Created++
+	case ImportStatusValid:
+		r.Valid++
+	default:
+		r.Failed++
+	}
+
+	if len(r.Rows) >= ImportMaxReportedRows {
+		r.Truncated = true
+		return
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// TeamMemberReader reads team members to create one row at a time, it returns io.EOF after
+// the last row and an error wrapping ErrInvalidRow for a row it can not decode.
+type TeamMemberReader interface {
+	Read() (TeamMemberCreateReq, error)
+}
+
+// NewTeamMemberReader reads r as CSV with a header row naming the name, username_github
+// and email columns in any order, or as JSON Lines of TeamMemberCreateReq.
+func NewTeamMemberReader(r io.Reader, format string) (TeamMemberReader, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		reader.ReuseRecord = true
+		return &csvTeamMemberReader{reader: reader}, nil
+	case FormatJSONL:
+		return &jsonlTeamMemberReader{reader: bufio.NewReader(r)}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type csvTeamMemberReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func (r *csvTeamMemberReader) Read() (TeamMemberCreateReq, error) {
+	if r.columns == nil {
+		header, err := r.reader.Read()
+		if err != nil {
+			return TeamMemberCreateReq{}, err
+		}
+
+		r.columns = map[string]int{}
+		for i, column := range header {
+			r.columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+		}
+		for _, column := range teamMemberImportColumns {
+			if _, ok := r.columns[column]; !ok {
+				return TeamMemberCreateReq{}, fmt.Errorf("csv header: missing column %s", column)
+			}
+		}
+	}
+
+	record, err := r.reader.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return TeamMemberCreateReq{}, fmt.Errorf("%w: %v", ErrInvalidRow, err)
+	}
+	if err != nil {
+		return TeamMemberCreateReq{}, err
+	}
+
+	value := func(column string) string {
+		i := r.columns[column]
+		if i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	return TeamMemberCreateReq{
+		Name:           value("name"),
+		UsernameGithub: value("username_github"),
+		Email:          value("email"),
+	}, nil
+}
+
+type jsonlTeamMemberReader struct {
+	reader *bufio.Reader
+}
+
+func (r *jsonlTeamMemberReader) Read() (TeamMemberCreateReq, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return TeamMemberCreateReq{}, err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if err != nil {
+				return TeamMemberCreateReq{}, err
+			}
+			// blank lines are not rows
+			continue
+		}
+
+		var req TeamMemberCreateReq
+		if err := json.Unmarshal(line, &req); err != nil {
+			return TeamMemberCreateReq{}, fmt.Errorf("%w: %v", ErrInvalidRow, err)
+		}
+		return req, nil
+	}
+}
+
+// TeamMemberWriter writes team members as CSV or JSON Lines, Flush must be called once done.
+type TeamMemberWriter interface {
+	Write(member models.TeamMember) error
+	Flush() error
+}
+
+// NewTeamMemberWriter writes to w as CSV, starting with a header row, or as JSON Lines.
+func NewTeamMemberWriter(w io.Writer, format string) (TeamMemberWriter, error) {
+	switch format {
+	case FormatCSV:
+		return &csvTeamMemberWriter{writer: csv.NewWriter(w)}, nil
+	case FormatJSONL:
+		return &jsonlTeamMemberWriter{writer: bufio.NewWriter(w)}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type csvTeamMemberWriter struct {
+	writer    *csv.Writer
+	hasHeader bool
+}
+
+func (w *csvTeamMemberWriter) Write(member models.TeamMember) error {
+	if !w.hasHeader {
+		w.hasHeader = true
+		if err := w.writer.Write(teamMemberExportColumns); err != nil {
+			return err
+		}
+	}
+
+	deletedAt := ""
+	if member.DeletedAt.Valid {
+		deletedAt = member.DeletedAt.Time.Format(time.RFC3339)
+	}
+
+	return w.writer.Write([]string{
+		strconv.FormatUint(member.ID, 10),
+		member.Name,
+		member.UsernameGithub,
+		member.Email,
+		member.CreatedAt.Format(time.RFC3339),
+		member.UpdatedAt.Format(time.RFC3339),
+		deletedAt,
+	})
+}
+
+func (w *csvTeamMemberWriter) Flush() error {
+	if !w.hasHeader {
+		w.hasHeader = true
+		if err := w.writer.Write(teamMemberExportColumns); err != nil {
+			return err
+		}
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type jsonlTeamMemberWriter struct {
+	writer *bufio.Writer
+}
+
+func (w *jsonlTeamMemberWriter) Write(member models.TeamMember) error {
+	line, err := json.Marshal(member)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.writer.Write(line); err != nil {
+		return err
+	}
+	return w.writer.WriteByte('\n')
+}
+
+func (w *jsonlTeamMemberWriter) Flush() error {
+	return w.writer.Flush()
+}
@@ -0,0 +1,176 @@
+package dto
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"gorm.io/gorm"
+)
+
+// readAll reads every row of reader, the rows it can not decode are nil.
+func readAll(reader TeamMemberReader) ([]*TeamMemberCreateReq, error) {
+	var rows []*TeamMemberCreateReq
+	for {
+		req, err := reader.Read()
+		switch {
+		case errors.Is(err, io.EOF):
+			return rows, nil
+		case errors.Is(err, ErrInvalidRow):
+			rows = append(rows, nil)
+		case err != nil:
+			return rows, err
+		default:
+			rows = append(rows, &req)
+		}
+	}
+}
+
+func TestNewTeamMemberReader(t *testing.T) {
+	adam := &TeamMemberCreateReq{Name: "Adam", UsernameGithub: "adam", Email: "adam@example.com"}
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []*TeamMemberCreateReq
+		wantErr bool
+	}{
+		{
+			name:   "csv columns in any order",
+			format: FormatCSV,
+			input:  "\ufeffEmail, name,username_github,extra\nadam@example.com,Adam,adam,x\n\n,,\n",
+			want:   []*TeamMemberCreateReq{adam, {}},
+		},
+		{
+			name:   "csv invalid row",
+			format: FormatCSV,
+			input:  "name,username_github,email\n\"Adam,adam\n",
+			want:   []*TeamMemberCreateReq{nil},
+		},
+		{
+			name:    "csv missing column",
+			format:  FormatCSV,
+			input:   "name,email\nAdam,adam@example.com\n",
+			wantErr: true,
+		},
+		{
+			name:   "jsonl",
+			format: FormatJSONL,
+			input:  "{\"name\": \"Adam\", \"username_github\": \"adam\", \"email\": \"adam@example.com\"}\n\n{bad}\n{\"name\": \"Adam\", \"username_github\": \"adam\", \"email\": \"adam@example.com\"}",
+			want:   []*TeamMemberCreateReq{adam, nil, adam},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewTeamMemberReader(strings.NewReader(tt.input), tt.format)
+			if err != nil {
+				t.Fatalf("NewTeamMemberReader() error = %v", err)
+			}
+
+			got, err := readAll(reader)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TeamMemberReader.Read() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewTeamMemberReader(strings.NewReader(""), "xml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("NewTeamMemberReader() error = %v, want %v", err, ErrUnknownFormat)
+	}
+}
+
+func TestNewTeamMemberWriter(t *testing.T) {
+	at := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	member := models.TeamMember{
+		ID:             1,
+		Name:           "Adam, N",
+		UsernameGithub: "adam",
+		Email:          "adam@example.com",
+		DefaultModel: models.DefaultModel{
+			CreatedAt: at,
+			UpdatedAt: at,
+			DeletedAt: gorm.DeletedAt{Time: at, Valid: true},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		members []models.TeamMember
+		want    string
+	}{
+		{
+			name:    "csv",
+			format:  FormatCSV,
+			members: []models.TeamMember{member},
+			want: "id,name,username_github,email,created_at,updated_at,deleted_at\n" +
+				"1,\"Adam, N\",adam,adam@example.com,2026-10-18T09:00:00Z,2026-10-18T09:00:00Z,2026-10-18T09:00:00Z\n",
+		},
+		{
+			name:   "csv without rows",
+			format: FormatCSV,
+			want:   "id,name,username_github,email,created_at,updated_at,deleted_at\n",
+		},
+		{
+			name:    "jsonl",
+			format:  FormatJSONL,
+			members: []models.TeamMember{{ID: 1}, {ID: 2}},
+			want:    "{\"id\":1,",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer, err := NewTeamMemberWriter(&buf, tt.format)
+			if err != nil {
+				t.Fatalf("NewTeamMemberWriter() error = %v", err)
+			}
+			for _, member := range tt.members {
+				if err := writer.Write(member); err != nil {
+					t.Fatalf("TeamMemberWriter.Write() error = %v", err)
+				}
+			}
+			if err := writer.Flush(); err != nil {
+				t.Fatalf("TeamMemberWriter.Flush() error = %v", err)
+			}
+
+			if tt.format == FormatJSONL {
+				lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+				if len(lines) != len(tt.members) || !strings.HasPrefix(lines[0], tt.want) {
+					t.Errorf("TeamMemberWriter = %q, want %d lines starting with %q", buf.String(), len(tt.members), tt.want)
+				}
+				return
+			}
+			if buf.String() != tt.want {
+				t.Errorf("TeamMemberWriter = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTeamMemberImportReport_Add(t *testing.T) {
+	report := &TeamMemberImportReport{}
+	for i := 1; i <= ImportMaxReportedRows+2; i++ {
+		status := ImportStatusCreated
+		if i%2 == 0 {
+			status = ImportStatusFailed
+		}
+		report.Add(TeamMemberImportRow{Row: i, Status: status})
+	}
+
+	if report.Created != ImportMaxReportedRows/2+1 || report.Failed != ImportMaxReportedRows/2+1 {
+		t.Errorf("TeamMemberImportReport counts = %d created, %d failed", report.Created, report.Failed)
+	}
+	if len(report.Rows) != ImportMaxReportedRows || !report.Truncated {
+		t.Errorf("TeamMemberImportReport = %d rows, truncated %v, want %d rows truncated", len(report.Rows), report.Truncated, ImportMaxReportedRows)
+	}
+}
@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// ErrNoCredentials is returned by an Authenticator when the request carries
+// no credentials of its kind, so the next Authenticator can be tried.
+var ErrNoCredentials = errors.New("no credentials")
+
+// ErrAuthUnavailable is returned by an Authenticator that could not check the
+// credentials, such as when the database is down, it answers 503 instead of 401.
+var ErrAuthUnavailable = errors.New("authentication unavailable")
+
+// Authenticator resolves the caller of a request.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*models.Caller, error)
+}
+
+// ChainAuthenticator tries each Authenticator in order until one finds credentials.
+type ChainAuthenticator []Authenticator
+
+func NewChainAuthenticator(authenticators ...Authenticator) ChainAuthenticator {
+	return ChainAuthenticator(authenticators)
+}
+
+func (c ChainAuthenticator) Authenticate(r *http.Request) (*models.Caller, error) {
+	for _, auth := range c {
+		caller, err := auth.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		return caller, err
+	}
+
+	return nil, ErrNoCredentials
+}
+
+// Authenticate rejects requests without a valid caller and stores the caller
+// in the request context for the handlers, see models.CallerFromContext.
+func Authenticate(next http.HandlerFunc, auth Authenticator) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, err := auth.Authenticate(r)
+		if errors.Is(err, ErrAuthUnavailable) {
+			w.Header().Set("Retry-After", "1")
+			WriteError(w, http.StatusServiceUnavailable, response_mapper.ErrDB())
+			return
+		}
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+			response_mapper.RenderJSON(w, http.StatusUnauthorized, errUnauthorized(err))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(models.ContextWithCaller(r.Context(), caller)))
+	})
+}
+
+func errUnauthorized(err error) *response_mapper.ResponseError {
+	if errors.Is(err, ErrNoCredentials) {
+		return response_mapper.NewError(response_mapper.ErrUnauthorized, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: "Kredensial dibutuhkan",
+				EN: "Credentials are required",
+			},
+		))
+	}
+
+	var respErr *response_mapper.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr
+	}
+
+	return response_mapper.NewError(response_mapper.ErrUnauthorized, response_mapper.NewResponseMultiLang(
+		response_mapper.MultiLanguages{
+			ID: "Token tidak valid",
+			EN: "Invalid token",
+		},
+	))
+}
@@ -0,0 +1,86 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const HeaderApiKey = "X-API-Key"
+
+// apiKeyCacheTimeOut bounds how long a key revoked or changed in Postgres is still accepted.
+const apiKeyCacheTimeOut = time.Minute
+
+// ApiKeyAuthenticator resolves callers from hashed API keys stored in Postgres,
+// keys found are cached in Redis for apiKeyCacheTimeOut.
+type ApiKeyAuthenticator struct {
+	Repo   repository.ApiKeyRepository
+	Logger *logrus.Logger
+	Now    func() time.Time
+}
+
+func NewApiKeyAuthenticator(repo repository.ApiKeyRepository, logger *logrus.Logger) *ApiKeyAuthenticator {
+	return &ApiKeyAuthenticator{
+		Repo:   repo,
+		Logger: logger,
+		Now:    time.Now,
+	}
+}
+
+func (a *ApiKeyAuthenticator) Authenticate(r *http.Request) (*models.Caller, error) {
+	var (
+		opName = "ApiKeyAuthenticator-Authenticate"
+		key    = strings.TrimSpace(r.Header.Get(HeaderApiKey))
+	)
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+
+	apiKey, err := a.getByHash(r.Context(), models.HashApiKey(key))
+	if err != nil {
+		a.Logger.Errorf("%v error: %v ", opName, err)
+		return nil, ErrAuthUnavailable
+	}
+
+	if apiKey == nil || !apiKey.IsActive(a.Now()) {
+		return nil, response_mapper.NewError(response_mapper.ErrUnauthorized, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: "API key tidak valid",
+				EN: "Invalid API key",
+			},
+		))
+	}
+
+	return &models.Caller{
+		ID:     apiKey.OwnerID,
+		Name:   apiKey.Name,
+		Role:   apiKey.Role,
+		Method: models.AuthMethodApiKey,
+	}, nil
+}
+
+// getByHash reads the key from the cache, or from Postgres on a miss. Unknown keys are
+// not cached so that a key is usable as soon as it is created.
+func (a *ApiKeyAuthenticator) getByHash(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+	var (
+		key    = models.KeyCacheApiKey(keyHash)
+		apiKey *models.ApiKey
+	)
+	if a.Repo.GetCache(ctx, key, &apiKey) && apiKey != nil {
+		return apiKey, nil
+	}
+
+	apiKey, err := a.Repo.GetByHash(ctx, keyHash)
+	if err != nil || apiKey == nil {
+		return nil, err
+	}
+
+	a.Repo.CreateCache(ctx, key, apiKey, apiKeyCacheTimeOut)
+	return apiKey, nil
+}
@@ -0,0 +1,156 @@
+package middlewares
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+const jwtAlgHS256 = "HS256"
+
+var (
+	ErrTokenMalformed = errors.New("token is malformed")
+	ErrTokenSignature = errors.New("token signature is invalid")
+	ErrTokenExpired   = errors.New("token is expired")
+	ErrTokenNotValid  = errors.New("token is not valid yet")
+	ErrTokenIssuer    = errors.New("token issuer is invalid")
+	ErrTokenSubject   = errors.New("token subject is invalid")
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// JWTClaims are the registered claims read from a bearer token, sub holds the caller id.
+type JWTClaims struct {
+	Subject   string `json:"sub"`
+	Name      string `json:"name,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// JWTAuthenticator verifies HMAC-SHA256 signed bearer tokens.
+type JWTAuthenticator struct {
+	Secret []byte
+	Issuer string
+	Leeway time.Duration
+	Now    func() time.Time
+}
+
+func NewJWTAuthenticator(secret, issuer string, leeway time.Duration) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		Secret: []byte(secret),
+		Issuer: issuer,
+		Leeway: leeway,
+		Now:    time.Now,
+	}
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*models.Caller, error) {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := a.Verify(strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil || id == 0 {
+		return nil, ErrTokenSubject
+	}
+
+	return &models.Caller{
+		ID:     id,
+		Name:   claims.Name,
+		Role:   claims.Role,
+		Method: models.AuthMethodJWT,
+	}, nil
+}
+
+// Verify checks the signature and time based claims of token.
+func (a *JWTAuthenticator) Verify(token string) (*JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+
+	var header jwtHeader
+	err := decodeJWTSegment(parts[0], &header)
+	if err != nil || header.Alg != jwtAlgHS256 {
+		return nil, ErrTokenMalformed
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+	if !hmac.Equal(signature, signJWT(a.Secret, parts[0]+"."+parts[1])) {
+		return nil, ErrTokenSignature
+	}
+
+	var claims JWTClaims
+	err = decodeJWTSegment(parts[1], &claims)
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	now := a.Now()
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(a.Leeway)) {
+		return nil, ErrTokenExpired
+	}
+	if claims.NotBefore > 0 && now.Add(a.Leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, ErrTokenNotValid
+	}
+	if a.Issuer != "" && claims.Issuer != a.Issuer {
+		return nil, ErrTokenIssuer
+	}
+
+	return &claims, nil
+}
+
+// Sign issues a token for claims with the authenticator secret.
+func (a *JWTAuthenticator) Sign(claims JWTClaims) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: jwtAlgHS256, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signJWT(a.Secret, unsigned)), nil
+}
+
+func signJWT(secret []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,349 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/stretchr/testify/mock"
+)
+
+var authNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestJWTAuthenticator() *JWTAuthenticator {
+	auth := NewJWTAuthenticator("secret", "go-skeleton", time.Second)
+	auth.Now = func() time.Time { return authNow }
+	return auth
+}
+
+func signTestToken(t *testing.T, secret string, claims JWTClaims) string {
+	auth := NewJWTAuthenticator(secret, "", 0)
+	token, err := auth.Sign(claims)
+	if err != nil {
+		t.Fatalf("failed sign token: %v", err)
+	}
+	return token
+}
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	validClaims := JWTClaims{
+		Subject:   "7",
+		Name:      "adam",
+		Issuer:    "go-skeleton",
+		ExpiresAt: authNow.Add(time.Hour).Unix(),
+	}
+	tests := []struct {
+		name    string
+		header  string
+		want    *models.Caller
+		wantErr error
+	}{
+		{
+			name:    "no header",
+			header:  "",
+			wantErr: ErrNoCredentials,
+		},
+		{
+			name:    "not a bearer token",
+			header:  "Basic dXNlcjpwYXNz",
+			wantErr: ErrNoCredentials,
+		},
+		{
+			name:    "malformed",
+			header:  "Bearer abc.def",
+			wantErr: ErrTokenMalformed,
+		},
+		{
+			name:    "signed with another secret",
+			header:  "Bearer " + signTestToken(t, "other", validClaims),
+			wantErr: ErrTokenSignature,
+		},
+		{
+			name: "expired",
+			header: "Bearer " + signTestToken(t, "secret", JWTClaims{
+				Subject:   "7",
+				Issuer:    "go-skeleton",
+				ExpiresAt: authNow.Add(-time.Minute).Unix(),
+			}),
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name: "not valid yet",
+			header: "Bearer " + signTestToken(t, "secret", JWTClaims{
+				Subject:   "7",
+				Issuer:    "go-skeleton",
+				NotBefore: authNow.Add(time.Minute).Unix(),
+				ExpiresAt: authNow.Add(time.Hour).Unix(),
+			}),
+			wantErr: ErrTokenNotValid,
+		},
+		{
+			name: "wrong issuer",
+			header: "Bearer " + signTestToken(t, "secret", JWTClaims{
+				Subject:   "7",
+				Issuer:    "someone-else",
+				ExpiresAt: authNow.Add(time.Hour).Unix(),
+			}),
+			wantErr: ErrTokenIssuer,
+		},
+		{
+			name: "subject is not an id",
+			header: "Bearer " + signTestToken(t, "secret", JWTClaims{
+				Subject:   "adam",
+				Issuer:    "go-skeleton",
+				ExpiresAt: authNow.Add(time.Hour).Unix(),
+			}),
+			wantErr: ErrTokenSubject,
+		},
+		{
+			name:   "success",
+			header: "Bearer " + signTestToken(t, "secret", validClaims),
+			want: &models.Caller{
+				ID:     7,
+				Name:   "adam",
+				Method: models.AuthMethodJWT,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			got, err := newTestJWTAuthenticator().Authenticate(r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("JWTAuthenticator.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JWTAuthenticator.Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApiKeyAuthenticator_Authenticate(t *testing.T) {
+	var (
+		key      = "plain-api-key"
+		cacheKey = models.KeyCacheApiKey(models.HashApiKey(key))
+		revoked  = authNow.Add(-time.Hour)
+		expired  = authNow.Add(-time.Minute)
+	)
+	tests := []struct {
+		name     string
+		key      string
+		mockFunc func(repo *mocks.ApiKeyRepository)
+		want     *models.Caller
+		wantErr  bool
+		// unavailable is set when the key could not be checked
+		unavailable bool
+	}{
+		{
+			name:    "no header",
+			key:     "",
+			wantErr: true,
+		},
+		{
+			name: "failed get api key",
+			key:  key,
+			mockFunc: func(repo *mocks.ApiKeyRepository) {
+				repo.On("GetCache", mock.Anything, cacheKey, mock.Anything).Return(false).Once()
+				repo.On("GetByHash", mock.Anything, models.HashApiKey(key)).Return(nil, errors.New("invalid")).Once()
+			},
+			wantErr:     true,
+			unavailable: true,
+		},
+		{
+			name: "unknown key",
+			key:  key,
+			mockFunc: func(repo *mocks.ApiKeyRepository) {
+				repo.On("GetCache", mock.Anything, cacheKey, mock.Anything).Return(false).Once()
+				repo.On("GetByHash", mock.Anything, models.HashApiKey(key)).Return(nil, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "revoked key",
+			key:  key,
+			mockFunc: func(repo *mocks.ApiKeyRepository) {
+				repo.On("GetCache", mock.Anything, cacheKey, mock.Anything).Return(false).Once()
+				repo.On("GetByHash", mock.Anything, models.HashApiKey(key)).Return(&models.ApiKey{ID: 1, OwnerID: 9, RevokedAt: &revoked}, nil).Once()
+				repo.On("CreateCache", mock.Anything, cacheKey, mock.Anything, apiKeyCacheTimeOut).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired key",
+			key:  key,
+			mockFunc: func(repo *mocks.ApiKeyRepository) {
+				repo.On("GetCache", mock.Anything, cacheKey, mock.Anything).Return(false).Once()
+				repo.On("GetByHash", mock.Anything, models.HashApiKey(key)).Return(&models.ApiKey{ID: 1, OwnerID: 9, ExpiredAt: &expired}, nil).Once()
+				repo.On("CreateCache", mock.Anything, cacheKey, mock.Anything, apiKeyCacheTimeOut).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			key:  key,
+			mockFunc: func(repo *mocks.ApiKeyRepository) {
+				repo.On("GetCache", mock.Anything, cacheKey, mock.Anything).Return(false).Once()
+				repo.On("GetByHash", mock.Anything, models.HashApiKey(key)).Return(&models.ApiKey{ID: 1, OwnerID: 9, Name: "sync-job"}, nil).Once()
+				repo.On("CreateCache", mock.Anything, cacheKey, mock.Anything, apiKeyCacheTimeOut).Once()
+			},
+			want: &models.Caller{
+				ID:     9,
+				Name:   "sync-job",
+				Method: models.AuthMethodApiKey,
+			},
+		},
+		{
+			name: "cached key",
+			key:  key,
+			mockFunc: func(repo *mocks.ApiKeyRepository) {
+				repo.On("GetCache", mock.Anything, cacheKey, mock.Anything).Run(func(args mock.Arguments) {
+					*args.Get(2).(**models.ApiKey) = &models.ApiKey{ID: 1, OwnerID: 9, Name: "sync-job", Role: models.RoleViewer}
+				}).Return(true).Once()
+			},
+			want: &models.Caller{
+				ID:     9,
+				Name:   "sync-job",
+				Role:   models.RoleViewer,
+				Method: models.AuthMethodApiKey,
+			},
+		},
+		{
+			name: "cached key revoked",
+			key:  key,
+			mockFunc: func(repo *mocks.ApiKeyRepository) {
+				repo.On("GetCache", mock.Anything, cacheKey, mock.Anything).Run(func(args mock.Arguments) {
+					*args.Get(2).(**models.ApiKey) = &models.ApiKey{ID: 1, OwnerID: 9, RevokedAt: &revoked}
+				}).Return(true).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg  = &configs.Configs{}
+				repo = &mocks.ApiKeyRepository{}
+				auth = NewApiKeyAuthenticator(repo, driver.Logger(cfg))
+			)
+			auth.Now = func() time.Time { return authNow }
+			if tt.mockFunc != nil {
+				tt.mockFunc(repo)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.key != "" {
+				r.Header.Set(HeaderApiKey, tt.key)
+			}
+
+			got, err := auth.Authenticate(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ApiKeyAuthenticator.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errors.Is(err, ErrAuthUnavailable) != tt.unavailable {
+				t.Errorf("ApiKeyAuthenticator.Authenticate() error = %v, unavailable %v", err, tt.unavailable)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApiKeyAuthenticator.Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	var (
+		repo  = &mocks.ApiKeyRepository{}
+		keyOk = "plain-api-key"
+		jwt   = newTestJWTAuthenticator()
+		auth  = NewChainAuthenticator(jwt, NewApiKeyAuthenticator(repo, driver.Logger(&configs.Configs{})))
+	)
+	repo.On("GetCache", mock.Anything, mock.Anything, mock.Anything).Return(false)
+	repo.On("CreateCache", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.On("GetByHash", mock.Anything, models.HashApiKey(keyOk)).Return(&models.ApiKey{ID: 1, OwnerID: 9}, nil)
+	repo.On("GetByHash", mock.Anything, models.HashApiKey("broken")).Return(nil, errors.New("connection refused"))
+	repo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, nil)
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantStatus int
+		wantCaller uint64
+	}{
+		{
+			name:       "no credentials",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid bearer token",
+			headers:    map[string]string{"Authorization": "Bearer invalid"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid api key",
+			headers:    map[string]string{HeaderApiKey: "unknown"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "failed get api key",
+			headers:    map[string]string{HeaderApiKey: "broken"},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "bearer token",
+			headers: map[string]string{"Authorization": "Bearer " + signTestToken(t, "secret", JWTClaims{
+				Subject:   "7",
+				Issuer:    "go-skeleton",
+				ExpiresAt: authNow.Add(time.Hour).Unix(),
+			})},
+			wantStatus: http.StatusOK,
+			wantCaller: 7,
+		},
+		{
+			name:       "api key",
+			headers:    map[string]string{HeaderApiKey: keyOk},
+			wantStatus: http.StatusOK,
+			wantCaller: 9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCaller uint64
+			handler := Authenticate(func(w http.ResponseWriter, r *http.Request) {
+				if caller, ok := models.CallerFromContext(r.Context()); ok {
+					gotCaller = caller.ID
+				}
+				w.WriteHeader(http.StatusOK)
+			}, auth)
+
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Authenticate() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("WWW-Authenticate") != ""; got != (tt.wantStatus == http.StatusUnauthorized) {
+				t.Errorf("Authenticate() WWW-Authenticate = %v, want it on 401 only", w.Header().Get("WWW-Authenticate"))
+			}
+			if gotCaller != tt.wantCaller {
+				t.Errorf("Authenticate() caller = %v, want %v", gotCaller, tt.wantCaller)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// Authorizer decides whether a role is granted a permission such as models.PermissionTeamMemberCreate.
+type Authorizer interface {
+	HasPermission(ctx context.Context, role string, permission string) (bool, error)
+}
+
+// Authorize rejects callers whose role is not granted permission, it must run after Authenticate.
+func Authorize(next http.HandlerFunc, authz Authorizer, permission string) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, ok := models.CallerFromContext(r.Context())
+		if !ok {
+			response_mapper.RenderJSON(w, http.StatusUnauthorized, errUnauthorized(ErrNoCredentials))
+			return
+		}
+
+		allowed, err := authz.HasPermission(r.Context(), caller.Role, permission)
+		if err != nil {
+			// the permissions could not be read, the caller is not known to be denied
+			w.Header().Set("Retry-After", "1")
+			WriteError(w, http.StatusServiceUnavailable, response_mapper.ErrDB())
+			return
+		}
+
+		if !allowed {
+			response_mapper.RenderJSON(w, http.StatusForbidden, response_mapper.ErrCannotHaveAccessResources())
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Guard bundles the authenticator and authorizer used to protect routes, and the
+// Idempotency used by the routes that accept an Idempotency-Key when it is set.
+type Guard struct {
+	Auth        Authenticator
+	Authz       Authorizer
+	Idempotency *Idempotency
+}
+
+func NewGuard(auth Authenticator, authz Authorizer) *Guard {
+	return &Guard{
+		Auth:  auth,
+		Authz: authz,
+	}
+}
+
+// Protect authenticates the caller and checks permission, an empty permission leaves the route public.
+func (g *Guard) Protect(next http.HandlerFunc, permission string) http.HandlerFunc {
+	if permission == "" {
+		return next
+	}
+
+	return Authenticate(Authorize(next, g.Authz, permission), g.Auth)
+}
+
+// ProtectWhen protects next with permission only for the requests matched by when, the others
+// are served as they are, such as a public list that needs a permission to include deleted rows.
+func (g *Guard) ProtectWhen(next http.HandlerFunc, permission string, when func(r *http.Request) bool) http.HandlerFunc {
+	protected := g.Protect(next, permission)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if when(r) {
+			protected.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Idempotent lets next be retried with an Idempotency-Key, it must run inside Protect.
+func (g *Guard) Idempotent(next http.HandlerFunc) http.HandlerFunc {
+	if g.Idempotency == nil {
+		return next
+	}
+
+	return g.Idempotency.Protect(next)
+}
@@ -0,0 +1,112 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+type fakeAuthenticator struct {
+	caller *models.Caller
+}
+
+func (a fakeAuthenticator) Authenticate(r *http.Request) (*models.Caller, error) {
+	if a.caller == nil {
+		return nil, ErrNoCredentials
+	}
+	return a.caller, nil
+}
+
+type fakeAuthorizer map[string][]string
+
+func (a fakeAuthorizer) HasPermission(ctx context.Context, role string, permission string) (bool, error) {
+	if role == "broken" {
+		return false, response_mapper.ErrDB()
+	}
+	for _, v := range a[role] {
+		if v == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestGuard_Protect(t *testing.T) {
+	authz := fakeAuthorizer{
+		models.RoleViewer: {models.PermissionTeamMemberRead},
+		models.RoleAdmin:  {models.PermissionTeamMemberRead, models.PermissionTeamMemberDelete},
+	}
+	tests := []struct {
+		name       string
+		caller     *models.Caller
+		permission string
+		wantStatus int
+	}{
+		{
+			name:       "public route",
+			caller:     nil,
+			permission: "",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unauthenticated",
+			caller:     nil,
+			permission: models.PermissionTeamMemberRead,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "role without permission",
+			caller:     &models.Caller{ID: 1, Role: models.RoleViewer},
+			permission: models.PermissionTeamMemberDelete,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "caller without role",
+			caller:     &models.Caller{ID: 1},
+			permission: models.PermissionTeamMemberRead,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "failed check permission",
+			caller:     &models.Caller{ID: 1, Role: "broken"},
+			permission: models.PermissionTeamMemberRead,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "granted",
+			caller:     &models.Caller{ID: 1, Role: models.RoleAdmin},
+			permission: models.PermissionTeamMemberDelete,
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := NewGuard(fakeAuthenticator{caller: tt.caller}, authz)
+			handler := guard.Protect(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}, tt.permission)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Guard.Protect() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAuthorize_WithoutCaller(t *testing.T) {
+	handler := Authorize(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, fakeAuthorizer{}, models.PermissionTeamMemberRead)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Authorize() status = %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+}
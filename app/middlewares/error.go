@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"net/http"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+)
+
+// WriteError writes err with status, unlike response_mapper.RenderJSON which picks the
+// status from the error type and answers database errors with 422.
+func WriteError(w http.ResponseWriter, status int, err *response_mapper.ResponseError) {
+	resp := *err
+	resp.Status = response_mapper.StatusMapping(status)
+	_ = response_mapper.WriteJSON(w, status, resp)
+}
+
+// ErrInternal is the body of an unexpected failure, whose error is logged rather than
+// sent since its text may describe the internals of the service.
+func ErrInternal() *response_mapper.ResponseError {
+	return response_mapper.NewError(response_mapper.ErrUnknown, response_mapper.NewResponseMultiLang(
+		response_mapper.MultiLanguages{
+			ID: "Terjadi kesalahan pada server",
+			EN: "Internal server error",
+		},
+	))
+}
@@ -0,0 +1,211 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTTL bounds how long a request that never finishes, a crashed
+	// instance for one, keeps its key locked. It outlives the server write timeout.
+	idempotencyLockTTL = 30 * time.Second
+)
+
+// idempotentRecord is stored under the key of a request, without a status while
+// the request is in progress and with the rendered response once it is answered.
+type idempotentRecord struct {
+	Fingerprint string      `json:"fingerprint"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// Idempotency replays the stored response of a request retried with the same
+// Idempotency-Key, so a client that lost a response can retry without writing twice.
+type Idempotency struct {
+	Cache   driver.RedisClient
+	TTL     time.Duration
+	LockTTL time.Duration
+	Logger  *logrus.Logger
+}
+
+func NewIdempotency(cache driver.RedisClient, ttl time.Duration, logger *logrus.Logger) *Idempotency {
+	return &Idempotency{
+		Cache:   cache,
+		TTL:     ttl,
+		LockTTL: idempotencyLockTTL,
+		Logger:  logger,
+	}
+}
+
+// Protect runs next once per Idempotency-Key of a caller, it must run after Authenticate.
+// The first request locks the key while it runs, a duplicate sent meanwhile gets 409 and
+// one sent after it gets the stored response, unless its method, path or body differ
+// which gets 422. Requests without the header or failing with 5xx are not stored, and
+// the key is not enforced while Redis is unavailable.
+func (i *Idempotency) Protect(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var opName = "Middleware-Idempotency"
+
+		key := strings.TrimSpace(r.Header.Get(HeaderIdempotencyKey))
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response_mapper.RenderJSON(w, http.StatusBadRequest, response_mapper.ErrInvalidFormat(HeaderIdempotencyKey, HeaderIdempotencyKey))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			i.Logger.Errorf("%v error read body: %v ", opName, err)
+			response_mapper.RenderJSON(w, http.StatusBadRequest, response_mapper.ErrGetRequest())
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var (
+			cacheKey = models.KeyIdempotency(idempotencyScope(r), key)
+			record   = idempotentRecord{Fingerprint: fingerprint(r, body)}
+		)
+		locked, err := i.Cache.SetNX(cacheKey, record, i.LockTTL)
+		if err != nil {
+			i.Logger.Errorf("%v error lock key: %v ", opName, err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !locked {
+			i.replay(w, cacheKey, record.Fingerprint)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// a server error may not have written anything, let the client retry it
+		if rec.status >= http.StatusInternalServerError {
+			err = i.Cache.Del(cacheKey)
+			if err != nil {
+				i.Logger.Errorf("%v error unlock key: %v ", opName, err)
+			}
+			return
+		}
+
+		record.Status = rec.status
+		record.Header = w.Header().Clone()
+		record.Header.Del(HeaderRequestID)
+		record.Body = rec.body.Bytes()
+		err = i.Cache.Set(cacheKey, record, i.TTL)
+		if err != nil {
+			i.Logger.Errorf("%v error store response: %v ", opName, err)
+		}
+	})
+}
+
+// replay writes the response stored under cacheKey for a request with fingerprint.
+func (i *Idempotency) replay(w http.ResponseWriter, cacheKey, fingerprint string) {
+	var (
+		opName = "Middleware-Idempotency-replay"
+		record idempotentRecord
+	)
+
+	data, err := i.Cache.Get(cacheKey)
+	if err == nil {
+		err = json.Unmarshal([]byte(data), &record)
+	}
+	switch {
+	case errors.Is(err, driver.ErrNil):
+		// the first request failed or expired since the lock was taken
+		writeIdempotencyInProgress(w)
+		return
+	case err != nil:
+		i.Logger.Errorf("%v error: %v ", opName, err)
+		WriteError(w, http.StatusInternalServerError, ErrInternal())
+		return
+	case record.Fingerprint != fingerprint:
+		response_mapper.RenderJSON(w, http.StatusUnprocessableEntity, response_mapper.NewError(response_mapper.ErrFromUseCase, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: "Idempotency-Key sudah dipakai untuk request lain",
+				EN: "Idempotency-Key was already used for another request",
+			},
+		)))
+		return
+	case record.Status == 0:
+		writeIdempotencyInProgress(w)
+		return
+	}
+
+	for name, values := range record.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(HeaderIdempotentReplayed, "true")
+	w.WriteHeader(record.Status)
+	_, _ = w.Write(record.Body)
+}
+
+func writeIdempotencyInProgress(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", "1")
+	response_mapper.RenderJSON(w, http.StatusConflict, response_mapper.NewError(response_mapper.ErrConflict, response_mapper.NewResponseMultiLang(
+		response_mapper.MultiLanguages{
+			ID: "Request dengan Idempotency-Key yang sama sedang diproses",
+			EN: "A request with the same Idempotency-Key is in progress",
+		},
+	)))
+}
+
+// idempotencyScope keeps the keys of callers apart, so one cannot replay the response of another.
+func idempotencyScope(r *http.Request) string {
+	caller, ok := models.CallerFromContext(r.Context())
+	if !ok {
+		return "anonymous"
+	}
+	return fmt.Sprintf("%s_%d", caller.Method, caller.ID)
+}
+
+// fingerprint identifies the request a key was first sent with.
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.Path)
+	_, _ = hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder writes through to the client and keeps a copy of the response.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,249 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+)
+
+// fakeCache is an in memory driver.RedisClient, every call fails when down is set.
+type fakeCache struct {
+	mu   sync.Mutex
+	data map[string]string
+	down bool
+}
+
+var errCacheDown = errors.New("redis is down")
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{data: map[string]string{}}
+}
+
+func (c *fakeCache) Del(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return errCacheDown
+	}
+	delete(c.data, key)
+	return nil
+}
+
+func (c *fakeCache) DelPattern(pattern string) (int64, error) {
+	return 0, nil
+}
+
+func (c *fakeCache) Set(key string, value interface{}, expDur time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return errCacheDown
+	}
+	payload, _ := json.Marshal(value)
+	c.data[key] = string(payload)
+	return nil
+}
+
+func (c *fakeCache) SetNX(key string, value interface{}, expDur time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return false, errCacheDown
+	}
+	if _, ok := c.data[key]; ok {
+		return false, nil
+	}
+	payload, _ := json.Marshal(value)
+	c.data[key] = string(payload)
+	return true, nil
+}
+
+func (c *fakeCache) Get(key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return "", errCacheDown
+	}
+	data, ok := c.data[key]
+	if !ok {
+		return "", driver.ErrNil
+	}
+	return data, nil
+}
+
+func (c *fakeCache) Incr(key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return 0, errCacheDown
+	}
+	n, _ := strconv.ParseInt(c.data[key], 10, 64)
+	c.data[key] = strconv.FormatInt(n+1, 10)
+	return n + 1, nil
+}
+
+func (c *fakeCache) Ping() error  { return nil }
+func (c *fakeCache) Close() error { return nil }
+
+func TestIdempotency_Protect(t *testing.T) {
+	var (
+		admin  = &models.Caller{ID: 1, Role: models.RoleAdmin, Method: models.AuthMethodApiKey}
+		viewer = &models.Caller{ID: 2, Role: models.RoleViewer, Method: models.AuthMethodApiKey}
+	)
+	type call struct {
+		caller     *models.Caller
+		key        string
+		body       string
+		wantStatus int
+		wantReplay bool
+	}
+	tests := []struct {
+		name      string
+		status    int
+		down      bool
+		inFlight  string
+		calls     []call
+		wantCalls int
+	}{
+		{
+			name:   "without key",
+			status: http.StatusCreated,
+			calls: []call{
+				{caller: admin, body: `{"name":"adam"}`, wantStatus: http.StatusCreated},
+				{caller: admin, body: `{"name":"adam"}`, wantStatus: http.StatusCreated},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "replays the stored response",
+			status: http.StatusCreated,
+			calls: []call{
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusCreated},
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusCreated, wantReplay: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "rejects the key reused with another body",
+			status: http.StatusCreated,
+			calls: []call{
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusCreated},
+				{caller: admin, key: "key-1", body: `{"name":"budi"}`, wantStatus: http.StatusUnprocessableEntity},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "keeps the keys of callers apart",
+			status: http.StatusCreated,
+			calls: []call{
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusCreated},
+				{caller: viewer, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusCreated},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:     "rejects a duplicate in progress",
+			status:   http.StatusCreated,
+			inFlight: "key-1",
+			calls: []call{
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusConflict},
+			},
+			wantCalls: 0,
+		},
+		{
+			name:   "does not store server errors",
+			status: http.StatusInternalServerError,
+			calls: []call{
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusInternalServerError},
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusInternalServerError},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "passes through while redis is down",
+			status: http.StatusCreated,
+			down:   true,
+			calls: []call{
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusCreated},
+				{caller: admin, key: "key-1", body: `{"name":"adam"}`, wantStatus: http.StatusCreated},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "key too long",
+			status: http.StatusCreated,
+			calls: []call{
+				{caller: admin, key: strings.Repeat("k", maxIdempotencyKeyLength+1), body: `{"name":"adam"}`, wantStatus: http.StatusBadRequest},
+			},
+			wantCalls: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cache = newFakeCache()
+				idem  = NewIdempotency(cache, time.Hour, driver.Logger(&configs.Configs{}))
+				calls int
+				first string
+			)
+			cache.down = tt.down
+			if tt.inFlight != "" {
+				// the lock taken by the same request still running
+				_, _ = cache.SetNX(models.KeyIdempotency("api_key_1", tt.inFlight), idempotentRecord{
+					Fingerprint: fingerprint(httptest.NewRequest(http.MethodPost, "/v1/team-members", nil), []byte(tt.calls[0].body)),
+				}, time.Minute)
+			}
+			handler := idem.Protect(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				body, _ := io.ReadAll(r.Body)
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set(HeaderRequestID, "req-1")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"call":%d,"body":%s}`, calls, body)))
+			})
+
+			for i, c := range tt.calls {
+				r := httptest.NewRequest(http.MethodPost, "/v1/team-members", strings.NewReader(c.body))
+				r = r.WithContext(models.ContextWithCaller(r.Context(), c.caller))
+				if c.key != "" {
+					r.Header.Set(HeaderIdempotencyKey, c.key)
+				}
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, r)
+
+				if w.Code != c.wantStatus {
+					t.Fatalf("call %d status = %v, want %v, body %s", i, w.Code, c.wantStatus, w.Body.String())
+				}
+				if got := w.Header().Get(HeaderIdempotentReplayed) == "true"; got != c.wantReplay {
+					t.Errorf("call %d replayed = %v, want %v", i, got, c.wantReplay)
+				}
+				if c.wantReplay {
+					if w.Body.String() != first {
+						t.Errorf("call %d body = %s, want %s", i, w.Body.String(), first)
+					}
+					if w.Header().Get("Content-Type") != "application/json" || w.Header().Get(HeaderRequestID) != "" {
+						t.Errorf("call %d header = %v, want the stored header without %v", i, w.Header(), HeaderRequestID)
+					}
+				}
+				if i == 0 {
+					first = w.Body.String()
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
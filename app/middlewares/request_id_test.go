@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantKept bool
+	}{
+		{
+			name:     "kept from client",
+			header:   "req-123",
+			wantKept: true,
+		},
+		{
+			name:     "generated when missing",
+			header:   "",
+			wantKept: false,
+		},
+		{
+			name:     "generated when too long",
+			header:   strings.Repeat("a", maxRequestIDLength+1),
+			wantKept: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = models.RequestIDFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(HeaderRequestID, tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if got == "" {
+				t.Fatalf("RequestID() context request id is empty")
+			}
+			if (got == tt.header) != tt.wantKept {
+				t.Errorf("RequestID() request id = %v, header %v, wantKept %v", got, tt.header, tt.wantKept)
+			}
+			if w.Header().Get(HeaderRequestID) != got {
+				t.Errorf("RequestID() response header = %v, want %v", w.Header().Get(HeaderRequestID), got)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	apiKeyPrefixLength = 8
+	apiKeySecretLength = 32
+)
+
+// ApiKey represents the model for an ApiKeys, only the hash of the key is stored
+type ApiKey struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	Name      string     `json:"name" gorm:"not null"`
+	OwnerID   uint64     `json:"owner_id" gorm:"not null;index"`
+	Role      string     `json:"role" gorm:"not null;default:viewer"`
+	Prefix    string     `json:"prefix" gorm:"not null"`
+	KeyHash   string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiredAt *time.Time `json:"expired_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	DefaultModel
+}
+
+func (ApiKey) TableName() string {
+	return "api_keys"
+}
+
+// IsActive reports whether the key is neither revoked nor expired at now.
+func (m *ApiKey) IsActive(now time.Time) bool {
+	if m.RevokedAt != nil {
+		return false
+	}
+
+	return m.ExpiredAt == nil || now.Before(*m.ExpiredAt)
+}
+
+// HashApiKey returns the value stored in ApiKey.KeyHash for a plain key.
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateApiKey returns a new random plain key and its display prefix.
+// The plain key is shown once to the owner and never stored.
+func GenerateApiKey() (key string, prefix string, err error) {
+	buf := make([]byte, apiKeySecretLength)
+	_, err = rand.Read(buf)
+	if err != nil {
+		return "", "", err
+	}
+
+	key = hex.EncodeToString(buf)
+	return key, key[:apiKeyPrefixLength], nil
+}
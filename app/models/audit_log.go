@@ -0,0 +1,142 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+
+	AuditEntityTeamMember = "team_member"
+)
+
+// AuditLog records who changed an entity, how, and the changed fields.
+type AuditLog struct {
+	ID          uint64       `json:"id" gorm:"primaryKey"`
+	ActorID     uint64       `json:"actor_id" gorm:"index"`
+	ActorMethod string       `json:"actor_method"`
+	Action      string       `json:"action" gorm:"not null"`
+	Entity      string       `json:"entity" gorm:"not null;index:idx_audit_logs_entity"`
+	EntityID    uint64       `json:"entity_id" gorm:"index:idx_audit_logs_entity"`
+	Changes     AuditChanges `json:"changes" gorm:"type:jsonb"`
+	RequestID   string       `json:"request_id"`
+	CreatedAt   time.Time    `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// NewAuditLog builds the audit entry of a mutation, the actor and request id are read from ctx.
+func NewAuditLog(ctx context.Context, action, entity string, entityID uint64, before, after interface{}) (*AuditLog, error) {
+	changes, err := NewAuditChanges(before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &AuditLog{
+		Action:    action,
+		Entity:    entity,
+		EntityID:  entityID,
+		Changes:   changes,
+		RequestID: RequestIDFromContext(ctx),
+	}
+	if caller, ok := CallerFromContext(ctx); ok {
+		log.ActorID = caller.ID
+		log.ActorMethod = caller.Method
+	}
+
+	return log, nil
+}
+
+// AuditChange holds the value of a field before and after a mutation.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChanges maps a json field name to its change, stored as jsonb.
+type AuditChanges map[string]AuditChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func (c *AuditChanges) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("audit changes: unsupported type")
+	}
+
+	return json.Unmarshal(raw, c)
+}
+
+// NewAuditChanges compares the json form of before and after and keeps the
+// fields that differ, a nil before or after stands for a missing entity.
+func NewAuditChanges(before, after interface{}) (AuditChanges, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := AuditChanges{}
+	for key, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[key]) {
+			changes[key] = AuditChange{Before: value, After: afterFields[key]}
+		}
+	}
+	for key, value := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			changes[key] = AuditChange{Before: nil, After: value}
+		}
+	}
+
+	return changes, nil
+}
+
+func auditFields(v interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return fields, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(raw, &fields)
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
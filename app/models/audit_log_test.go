@@ -0,0 +1,142 @@
+package models
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAuditLog_TableName(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   AuditLog
+		want string
+	}{
+		{
+			name: "success",
+			tr:   AuditLog{},
+			want: "audit_logs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.TableName(); got != tt.want {
+				t.Errorf("AuditLog.TableName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAuditChanges(t *testing.T) {
+	before := &TeamMember{ID: 1, Name: "adam", Email: "adam@example.com"}
+	after := &TeamMember{ID: 1, Name: "adam nasrudin", Email: "adam@example.com"}
+
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   AuditChanges
+	}{
+		{
+			name:   "update keeps changed fields only",
+			before: before,
+			after:  after,
+			want: AuditChanges{
+				"name": {Before: "adam", After: "adam nasrudin"},
+			},
+		},
+		{
+			name:   "create has no before",
+			before: nil,
+			after:  map[string]interface{}{"name": "adam"},
+			want: AuditChanges{
+				"name": {Before: nil, After: "adam"},
+			},
+		},
+		{
+			name:   "typed nil before",
+			before: (*TeamMember)(nil),
+			after:  map[string]interface{}{"id": 1},
+			want: AuditChanges{
+				"id": {Before: nil, After: float64(1)},
+			},
+		},
+		{
+			name:   "no changes",
+			before: before,
+			after:  before,
+			want:   AuditChanges{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAuditChanges(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("NewAuditChanges() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAuditChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAuditLog(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	ctx = ContextWithCaller(ctx, &Caller{ID: 7, Method: AuthMethodJWT})
+
+	got, err := NewAuditLog(ctx, AuditActionCreate, AuditEntityTeamMember, 1, nil, map[string]interface{}{"name": "adam"})
+	if err != nil {
+		t.Fatalf("NewAuditLog() error = %v", err)
+	}
+
+	want := &AuditLog{
+		ActorID:     7,
+		ActorMethod: AuthMethodJWT,
+		Action:      AuditActionCreate,
+		Entity:      AuditEntityTeamMember,
+		EntityID:    1,
+		Changes:     AuditChanges{"name": {Before: nil, After: "adam"}},
+		RequestID:   "req-1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAuditLog() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAuditChanges_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    AuditChanges
+		wantErr bool
+	}{
+		{
+			name:  "bytes",
+			value: []byte(`{"name":{"before":"a","after":"b"}}`),
+			want:  AuditChanges{"name": {Before: "a", After: "b"}},
+		},
+		{
+			name:  "nil",
+			value: nil,
+			want:  nil,
+		},
+		{
+			name:    "unsupported",
+			value:   1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got AuditChanges
+			err := got.Scan(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AuditChanges.Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AuditChanges.Scan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import "context"
+
+const (
+	AuthMethodJWT    = "jwt"
+	AuthMethodApiKey = "api_key"
+	// AuthMethodCLI marks the changes made by the commands of the binary.
+	AuthMethodCLI = "cli"
+)
+
+// Caller is the authenticated identity behind a request.
+type Caller struct {
+	ID     uint64 `json:"id"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	Method string `json:"method"`
+}
+
+type callerCtxKey struct{}
+
+// ContextWithCaller returns a copy of ctx carrying the caller.
+func ContextWithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerCtxKey{}, caller)
+}
+
+// CallerFromContext returns the caller stored by ContextWithCaller.
+func CallerFromContext(ctx context.Context) (*Caller, bool) {
+	caller, ok := ctx.Value(callerCtxKey{}).(*Caller)
+	return caller, ok && caller != nil
+}
@@ -29,7 +29,12 @@ type BasedFilter struct {
 	SortBy            string `json:"sort_by" query:"sort_by"`
 	IsNoLimit         bool   `json:"is_no_limit" query:"is_no_limit"`
 	IsNotDefaultQuery bool   `json:"is_not_default_query" query:"is_not_default_query"`
-	CustomColumns     string `json:"custom_columns" query:"custom_columns"`
+	Sort              string `json:"sort" query:"sort"`
+	Fields            string `json:"fields" query:"fields"`
+
+	// Sorts and Selects are Sort and Fields checked against the Fields of the listed model.
+	Sorts   []Sort   `json:"-" query:"-"`
+	Selects []string `json:"-" query:"-"`
 }
 
 func (c *BasedFilter) DefaultQuery() BasedFilter {
@@ -2,6 +2,37 @@ package models
 
 import "fmt"
 
+// CacheKeyPatterns match every key the application caches, see driver.RedisClient.DelPattern.
+var CacheKeyPatterns = []string{
+	"team_member_detail_*",
+	"team_member_list_*",
+	"role_permissions_*",
+	"api_key_*",
+}
+
+// KeyCacheTeamMemberListGeneration holds the generation of the cached team member lists,
+// bumped by every write so that the pages of the previous generation are no longer read.
+const KeyCacheTeamMemberListGeneration = "team_member_list_generation"
+
+func KeyCacheTeamMemberList(generation int64, hash string) string {
+	return fmt.Sprintf("team_member_list_%d_%s", generation, hash)
+}
+
 func KeyCacheTeamMemberDetail(id uint64) string {
 	return fmt.Sprintf("team_member_detail_%d", id)
 }
+
+func KeyCacheRolePermissions(role string) string {
+	return fmt.Sprintf("role_permissions_%s", role)
+}
+
+// KeyCacheApiKey is keyed by the hash of the key, the plain key is never written to Redis.
+func KeyCacheApiKey(keyHash string) string {
+	return fmt.Sprintf("api_key_%s", keyHash)
+}
+
+// KeyIdempotency is the key of the response stored for an Idempotency-Key sent by caller.
+// It is not in CacheKeyPatterns, flushing the cache must not forget the requests already answered.
+func KeyIdempotency(caller, key string) string {
+	return fmt.Sprintf("idempotency_%s_%s", caller, key)
+}
@@ -27,3 +27,29 @@ func TestKeyCacheTeamMemberDetail(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyCacheRolePermissions(t *testing.T) {
+	type args struct {
+		role string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "success",
+			args: args{
+				role: RoleAdmin,
+			},
+			want: "role_permissions_admin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyCacheRolePermissions(tt.args.role); got != tt.want {
+				t.Errorf("KeyCacheRolePermissions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
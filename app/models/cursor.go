@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Cursor is the position of a row in a keyset ordered list, it travels signed as ?cursor=.
+type Cursor struct {
+	SortBy   string `json:"s"`
+	OrderBy  string `json:"o"`
+	Value    string `json:"v,omitempty"`
+	ID       uint64 `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// Sort is the order of the list the cursor points into.
+func (c Cursor) Sort() Sort {
+	return Sort{Column: c.SortBy, Desc: c.OrderBy == OrderByDESC}
+}
+
+// Arg is the Value of the cursor typed as its SortBy column.
+func (c Cursor) Arg() (interface{}, error) {
+	switch c.SortBy {
+	case "id":
+		return c.ID, nil
+	case "created_at", "updated_at":
+		return time.Parse(time.RFC3339Nano, c.Value)
+	default:
+		return c.Value, nil
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursor_Arg(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       Cursor
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "id",
+			c:    Cursor{SortBy: "id", ID: 7},
+			want: uint64(7),
+		},
+		{
+			name: "string column",
+			c:    Cursor{SortBy: "email", Value: "adam@example.com", ID: 7},
+			want: "adam@example.com",
+		},
+		{
+			name: "time column",
+			c:    Cursor{SortBy: "created_at", Value: "2024-01-01T20:04:05.000006Z", ID: 7},
+			want: time.Date(2024, 1, 1, 20, 4, 5, 6000, time.UTC),
+		},
+		{
+			name:    "invalid time",
+			c:       Cursor{SortBy: "updated_at", Value: "yesterday", ID: 7},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Arg()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Cursor.Arg() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cursor.Arg() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
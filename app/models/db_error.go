@@ -0,0 +1,40 @@
+package models
+
+import "fmt"
+
+const (
+	DBErrUniqueViolation      = "unique_violation"
+	DBErrForeignKeyViolation  = "foreign_key_violation"
+	DBErrNotNullViolation     = "not_null_violation"
+	DBErrCheckViolation       = "check_violation"
+	DBErrSerializationFailure = "serialization_failure"
+	// DBErrVersionMismatch is a write expecting another version of the row than the stored one.
+	DBErrVersionMismatch = "version_mismatch"
+)
+
+// DBError is a database constraint or concurrency failure, Field is the json
+// name of the column involved when it is known.
+type DBError struct {
+	Kind       string
+	Constraint string
+	Field      string
+	Err        error
+}
+
+// NewVersionMismatch is the error of a write to the row id expecting version while it is at stored.
+func NewVersionMismatch(id, stored, version uint64) *DBError {
+	return &DBError{
+		Kind:       DBErrVersionMismatch,
+		Constraint: "version",
+		Field:      "version",
+		Err:        fmt.Errorf("row %d is at version %d, not %d", id, stored, version),
+	}
+}
+
+func (e *DBError) Error() string {
+	return fmt.Sprintf("%s on %s: %v", e.Kind, e.Constraint, e.Err)
+}
+
+func (e *DBError) Unwrap() error {
+	return e.Err
+}
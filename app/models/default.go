@@ -1,10 +1,16 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type DefaultModel struct {
-	CreatedBy uint64    `json:"created_by"`
-	CreatedAt time.Time `json:"created_at,omitempty" gorm:"autoCreateTime"`
-	UpdatedBy uint64    `json:"updated_by"`
-	UpdatedAt time.Time `json:"updated_at,omitempty" gorm:"autoUpdateTime"`
+	CreatedBy uint64         `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at,omitempty" gorm:"autoCreateTime"`
+	UpdatedBy uint64         `json:"updated_by"`
+	UpdatedAt time.Time      `json:"updated_at,omitempty" gorm:"autoUpdateTime"`
+	DeletedBy uint64         `json:"deleted_by,omitempty"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	ParamSort   = "sort"
+	ParamFields = "fields"
+)
+
+// Field declares how list queries may use a column of a model.
+type Field struct {
+	Column     string
+	Type       string
+	Sortable   bool
+	Filterable bool
+	Selectable bool
+}
+
+// Fields maps the json name of each field of a model exposed to list queries to its Field,
+// names missing from it are rejected so no request value reaches SQL unchecked.
+type Fields map[string]Field
+
+var (
+	TeamMemberFields = Fields{
+		"id":              {Column: "id", Type: FieldTypeNumber, Sortable: true, Filterable: true, Selectable: true},
+		"name":            {Column: "name", Type: FieldTypeString, Sortable: true, Filterable: true, Selectable: true},
+		"username_github": {Column: "username_github", Type: FieldTypeString, Sortable: true, Filterable: true, Selectable: true},
+		"email":           {Column: "email", Type: FieldTypeString, Sortable: true, Filterable: true, Selectable: true},
+		"version":         {Column: "version", Type: FieldTypeNumber, Selectable: true},
+		"created_by":      {Column: "created_by", Type: FieldTypeNumber, Filterable: true, Selectable: true},
+		"created_at":      {Column: "created_at", Type: FieldTypeTime, Sortable: true, Filterable: true, Selectable: true},
+		"updated_by":      {Column: "updated_by", Type: FieldTypeNumber, Filterable: true, Selectable: true},
+		"updated_at":      {Column: "updated_at", Type: FieldTypeTime, Sortable: true, Filterable: true, Selectable: true},
+		"deleted_by":      {Column: "deleted_by", Type: FieldTypeNumber, Filterable: true, Selectable: true},
+		"deleted_at":      {Column: "deleted_at", Type: FieldTypeTime, Sortable: true, Filterable: true, Selectable: true},
+	}
+
+	AuditLogFields = Fields{
+		"id":           {Column: "id", Type: FieldTypeNumber, Sortable: true, Filterable: true, Selectable: true},
+		"actor_id":     {Column: "actor_id", Type: FieldTypeNumber, Filterable: true, Selectable: true},
+		"actor_method": {Column: "actor_method", Type: FieldTypeString, Filterable: true, Selectable: true},
+		"action":       {Column: "action", Type: FieldTypeString, Filterable: true, Selectable: true},
+		"entity":       {Column: "entity", Type: FieldTypeString, Filterable: true, Selectable: true},
+		"entity_id":    {Column: "entity_id", Type: FieldTypeNumber, Filterable: true, Selectable: true},
+		"changes":      {Column: "changes", Type: FieldTypeString, Selectable: true},
+		"request_id":   {Column: "request_id", Type: FieldTypeString, Filterable: true, Selectable: true},
+		"created_at":   {Column: "created_at", Type: FieldTypeTime, Sortable: true, Filterable: true, Selectable: true},
+	}
+)
+
+// FieldError reports a field of Param that is unknown, not allowed there or given an invalid value.
+type FieldError struct {
+	Param string
+	Name  string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: invalid field %q", e.Param, e.Name)
+}
+
+// Sort is one column of an ORDER BY.
+type Sort struct {
+	Column string
+	Desc   bool
+}
+
+func (s Sort) String() string {
+	if s.Desc {
+		return s.Column + " " + OrderByDESC
+	}
+	return s.Column + " " + OrderByASC
+}
+
+// ParseSort reads a comma separated list of sortable fields such as -created_at,name,
+// a leading - sorts the field descending and repeated fields keep their first position.
+func (f Fields) ParseSort(value string) ([]Sort, error) {
+	var (
+		sorts []Sort
+		seen  = map[string]bool{}
+	)
+	for _, name := range splitFields(value) {
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		field, ok := f[name]
+		if !ok || !field.Sortable {
+			return nil, &FieldError{Param: ParamSort, Name: name}
+		}
+		if seen[field.Column] {
+			continue
+		}
+
+		seen[field.Column] = true
+		sorts = append(sorts, Sort{Column: field.Column, Desc: desc})
+	}
+
+	return sorts, nil
+}
+
+// ParseSelect reads a comma separated list of selectable fields, id is always included.
+func (f Fields) ParseSelect(value string) ([]string, error) {
+	names := splitFields(value)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	selects := []string{"id"}
+	seen := map[string]bool{"id": true}
+	for _, name := range names {
+		field, ok := f[name]
+		if !ok || !field.Selectable {
+			return nil, &FieldError{Param: ParamFields, Name: name}
+		}
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		selects = append(selects, name)
+	}
+
+	return selects, nil
+}
+
+// Columns are the columns of names, plus the sort columns a keyset page is read by.
+func (f Fields) Columns(names []string, sorts ...Sort) []string {
+	var (
+		columns []string
+		seen    = map[string]bool{}
+	)
+	for _, name := range names {
+		if field, ok := f[name]; ok && !seen[field.Column] {
+			seen[field.Column] = true
+			columns = append(columns, field.Column)
+		}
+	}
+	for _, sort := range sorts {
+		if !seen[sort.Column] {
+			seen[sort.Column] = true
+			columns = append(columns, sort.Column)
+		}
+	}
+
+	return columns
+}
+
+// Project keeps the json fields in names of every element of the slice data.
+func Project(data interface{}, names []string) ([]map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []map[string]json.RawMessage
+	err = json.Unmarshal(raw, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]map[string]json.RawMessage, 0, len(rows))
+	for _, row := range rows {
+		projected := make(map[string]json.RawMessage, len(names))
+		for _, name := range names {
+			if value, ok := row[name]; ok {
+				projected[name] = value
+			}
+		}
+		resp = append(resp, projected)
+	}
+
+	return resp, nil
+}
+
+func splitFields(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
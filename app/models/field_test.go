@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFields_ParseSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []Sort
+		wantErr *FieldError
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  nil,
+		},
+		{
+			name:  "multi column with descending",
+			value: "-created_at, Name",
+			want:  []Sort{{Column: "created_at", Desc: true}, {Column: "name"}},
+		},
+		{
+			name:  "repeated field keeps first",
+			value: "name,-name,id",
+			want:  []Sort{{Column: "name"}, {Column: "id"}},
+		},
+		{
+			name:    "unknown field",
+			value:   "name,password",
+			wantErr: &FieldError{Param: ParamSort, Name: "password"},
+		},
+		{
+			name:    "field not sortable",
+			value:   "-created_by",
+			wantErr: &FieldError{Param: ParamSort, Name: "created_by"},
+		},
+		{
+			name:    "injection",
+			value:   "id; DROP TABLE team_members",
+			wantErr: &FieldError{Param: ParamSort, Name: "id; drop table team_members"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TeamMemberFields.ParseSort(tt.value)
+			if tt.wantErr != nil {
+				var fieldErr *FieldError
+				if !errors.As(err, &fieldErr) || *fieldErr != *tt.wantErr {
+					t.Errorf("Fields.ParseSort() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Fields.ParseSort() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields.ParseSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFields_ParseSelect(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "empty selects every field",
+			value: " , ",
+			want:  nil,
+		},
+		{
+			name:  "id always included",
+			value: "name,email,name",
+			want:  []string{"id", "name", "email"},
+		},
+		{
+			name:    "unknown field",
+			value:   "name,*",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TeamMemberFields.ParseSelect(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Fields.ParseSelect() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields.ParseSelect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFields_Columns(t *testing.T) {
+	got := TeamMemberFields.Columns([]string{"id", "name"}, Sort{Column: "created_at", Desc: true}, Sort{Column: "name"})
+	want := []string{"id", "name", "created_at"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields.Columns() = %v, want %v", got, want)
+	}
+}
+
+func TestProject(t *testing.T) {
+	got, err := Project([]TeamMember{{ID: 1, Name: "adam", Email: "adam@example.com"}}, []string{"id", "name"})
+	if err != nil {
+		t.Fatalf("Project() error = %v", err)
+	}
+
+	raw, _ := json.Marshal(got)
+	if want := `[{"id":1,"name":"adam"}]`; string(raw) != want {
+		t.Errorf("Project() = %s, want %s", raw, want)
+	}
+}
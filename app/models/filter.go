@@ -0,0 +1,151 @@
+package models
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ParamFilter = "filter"
+
+	FilterEq    = "eq"
+	FilterNe    = "ne"
+	FilterGt    = "gt"
+	FilterGte   = "gte"
+	FilterLt    = "lt"
+	FilterLte   = "lte"
+	FilterLike  = "like"
+	FilterIlike = "ilike"
+	FilterIn    = "in"
+	FilterNull  = "null"
+
+	FieldTypeString = "string"
+	FieldTypeNumber = "number"
+	FieldTypeTime   = "time"
+)
+
+// filterOperators are the operators allowed on each field type.
+var filterOperators = map[string]map[string]bool{
+	FieldTypeString: {FilterEq: true, FilterNe: true, FilterLike: true, FilterIlike: true, FilterIn: true, FilterNull: true},
+	FieldTypeNumber: {FilterEq: true, FilterNe: true, FilterGt: true, FilterGte: true, FilterLt: true, FilterLte: true, FilterIn: true, FilterNull: true},
+	FieldTypeTime:   {FilterEq: true, FilterNe: true, FilterGt: true, FilterGte: true, FilterLt: true, FilterLte: true, FilterNull: true},
+}
+
+// filterKey matches filter[field][operator] and filter[or][field][operator], the operator defaults to eq.
+var filterKey = regexp.MustCompile(`^filter(\[or\])?\[([a-z_]+)\](?:\[([a-z]+)\])?$`)
+
+// Condition compares Column with Value, Value is typed after the field:
+// a string, uint64 or time.Time, a slice of them for in and a bool for null.
+type Condition struct {
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+// Filter is a tree of conditions, all of them must hold or any of them when Or is set.
+type Filter struct {
+	Or         bool
+	Conditions []Condition
+	Groups     []Filter
+}
+
+// IsEmpty reports whether the filter has no condition.
+func (f Filter) IsEmpty() bool {
+	return len(f.Conditions) == 0 && len(f.Groups) == 0
+}
+
+// ParseFilter reads filter[field][operator]=value parameters into a Filter checked against
+// the filterable fields, conditions under filter[or] form a group where any may hold.
+func (f Fields) ParseFilter(values url.Values) (Filter, error) {
+	var (
+		filter Filter
+		or     = Filter{Or: true}
+		keys   = make([]string, 0, len(values))
+	)
+	for key := range values {
+		keys = append(keys, key)
+	}
+	// map order is random, sorted keys keep the generated SQL stable
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, ParamFilter+"[") {
+			continue
+		}
+
+		match := filterKey.FindStringSubmatch(strings.ToLower(key))
+		if match == nil {
+			return Filter{}, &FieldError{Param: ParamFilter, Name: key}
+		}
+
+		name, operator := match[2], match[3]
+		if operator == "" {
+			operator = FilterEq
+		}
+
+		field, ok := f[name]
+		if !ok || !field.Filterable || !filterOperators[field.Type][operator] {
+			return Filter{}, &FieldError{Param: ParamFilter, Name: key}
+		}
+
+		for _, raw := range values[key] {
+			value, err := parseFilterValue(field.Type, operator, raw)
+			if err != nil {
+				return Filter{}, &FieldError{Param: ParamFilter, Name: key}
+			}
+
+			condition := Condition{Column: field.Column, Operator: operator, Value: value}
+			if match[1] != "" {
+				or.Conditions = append(or.Conditions, condition)
+			} else {
+				filter.Conditions = append(filter.Conditions, condition)
+			}
+		}
+	}
+
+	if !or.IsEmpty() {
+		filter.Groups = append(filter.Groups, or)
+	}
+
+	return filter, nil
+}
+
+func parseFilterValue(fieldType, operator, raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	switch operator {
+	case FilterNull:
+		return strconv.ParseBool(raw)
+	case FilterIn:
+		var values []interface{}
+		for _, item := range strings.Split(raw, ",") {
+			value, err := parseFieldValue(fieldType, item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	default:
+		return parseFieldValue(fieldType, raw)
+	}
+}
+
+func parseFieldValue(fieldType, raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	switch fieldType {
+	case FieldTypeNumber:
+		return strconv.ParseUint(raw, 10, 64)
+	case FieldTypeTime:
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return time.Parse(time.DateOnly, raw)
+		}
+		return value.UTC(), nil
+	default:
+		return raw, nil
+	}
+}
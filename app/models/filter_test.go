@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFields_ParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  url.Values
+		want    Filter
+		wantErr bool
+	}{
+		{
+			name:   "no filter",
+			values: url.Values{"page": {"1"}, "search": {"adam"}},
+			want:   Filter{},
+		},
+		{
+			name: "typed conditions",
+			values: url.Values{
+				"filter[name][ilike]":      {"adam"},
+				"filter[created_at][gte]":  {"2026-01-01"},
+				"filter[created_at][lt]":   {"2026-02-01T07:00:00+07:00"},
+				"filter[created_by]":       {"3"},
+				"filter[id][in]":           {"1, 2"},
+				"filter[deleted_at][null]": {"false"},
+			},
+			want: Filter{
+				Conditions: []Condition{
+					{Column: "created_at", Operator: FilterGte, Value: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Column: "created_at", Operator: FilterLt, Value: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+					{Column: "created_by", Operator: FilterEq, Value: uint64(3)},
+					{Column: "deleted_at", Operator: FilterNull, Value: false},
+					{Column: "id", Operator: FilterIn, Value: []interface{}{uint64(1), uint64(2)}},
+					{Column: "name", Operator: FilterIlike, Value: "adam"},
+				},
+			},
+		},
+		{
+			name: "or group",
+			values: url.Values{
+				"filter[or][email][ilike]":           {"adam"},
+				"filter[or][username_github][ilike]": {"adam"},
+				"filter[created_by]":                 {"3"},
+			},
+			want: Filter{
+				Conditions: []Condition{
+					{Column: "created_by", Operator: FilterEq, Value: uint64(3)},
+				},
+				Groups: []Filter{
+					{
+						Or: true,
+						Conditions: []Condition{
+							{Column: "email", Operator: FilterIlike, Value: "adam"},
+							{Column: "username_github", Operator: FilterIlike, Value: "adam"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "unknown field",
+			values:  url.Values{"filter[password]": {"secret"}},
+			wantErr: true,
+		},
+		{
+			name:    "operator not allowed on type",
+			values:  url.Values{"filter[created_at][ilike]": {"2026"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown operator",
+			values:  url.Values{"filter[name][regex]": {".*"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid number",
+			values:  url.Values{"filter[created_by]": {"1 OR 1=1"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time",
+			values:  url.Values{"filter[created_at][gte]": {"yesterday"}},
+			wantErr: true,
+		},
+		{
+			name:    "malformed key",
+			values:  url.Values{"filter[name][ilike][0]": {"adam"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TeamMemberFields.ParseFilter(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Fields.ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				var fieldErr *FieldError
+				if !errors.As(err, &fieldErr) || fieldErr.Param != ParamFilter {
+					t.Errorf("Fields.ParseFilter() error = %v, want a filter FieldError", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields.ParseFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+import "fmt"
+
+func KeyLockTeamMemberEmail(email string) string {
+	return fmt.Sprintf("team_member_email_%s", email)
+}
+
+func KeyLockTeamMemberUsernameGithub(usernameGithub string) string {
+	return fmt.Sprintf("team_member_username_github_%s", usernameGithub)
+}
@@ -0,0 +1,45 @@
+package models
+
+import "testing"
+
+func TestKeyLockTeamMemberEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{
+			name:  "success",
+			email: "adam@example.com",
+			want:  "team_member_email_adam@example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyLockTeamMemberEmail(tt.email); got != tt.want {
+				t.Errorf("KeyLockTeamMemberEmail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyLockTeamMemberUsernameGithub(t *testing.T) {
+	tests := []struct {
+		name           string
+		usernameGithub string
+		want           string
+	}{
+		{
+			name:           "success",
+			usernameGithub: "adamnasrudin03",
+			want:           "team_member_username_github_adamnasrudin03",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyLockTeamMemberUsernameGithub(tt.usernameGithub); got != tt.want {
+				t.Errorf("KeyLockTeamMemberUsernameGithub() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+// Meta describes the page returned by a list endpoint.
+type Meta struct {
+	Page          int   `json:"page"`
+	Limit         int   `json:"limit"`
+	TotalRecords  int64 `json:"total_records"`
+	TotalPages    int   `json:"total_pages"`
+	HasNext       bool  `json:"has_next"`
+	HasPrev       bool  `json:"has_prev"`
+	IsApproximate bool  `json:"is_approximate,omitempty"`
+
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Pagination is a page of Data with its Meta.
+type Pagination struct {
+	Meta Meta
+	Data interface{}
+}
+
+// NewMeta computes the page counts of total records split by limit,
+// a limit of 0 or less puts every record on a single page.
+func NewMeta(page, limit int, total int64) Meta {
+	meta := Meta{
+		Page:         page,
+		Limit:        limit,
+		TotalRecords: total,
+	}
+
+	switch {
+	case total <= 0:
+		meta.TotalPages = 0
+	case limit <= 0:
+		meta.TotalPages = 1
+	default:
+		meta.TotalPages = int((total + int64(limit) - 1) / int64(limit))
+	}
+
+	meta.HasNext = page < meta.TotalPages
+	meta.HasPrev = page > 1
+	return meta
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewMeta(t *testing.T) {
+	type args struct {
+		page  int
+		limit int
+		total int64
+	}
+	tests := []struct {
+		name string
+		args args
+		want Meta
+	}{
+		{
+			name: "empty",
+			args: args{page: 1, limit: 10, total: 0},
+			want: Meta{Page: 1, Limit: 10},
+		},
+		{
+			name: "total equals limit",
+			args: args{page: 1, limit: 10, total: 10},
+			want: Meta{Page: 1, Limit: 10, TotalRecords: 10, TotalPages: 1},
+		},
+		{
+			name: "one record over the page boundary",
+			args: args{page: 1, limit: 10, total: 11},
+			want: Meta{Page: 1, Limit: 10, TotalRecords: 11, TotalPages: 2, HasNext: true},
+		},
+		{
+			name: "last page",
+			args: args{page: 2, limit: 10, total: 11},
+			want: Meta{Page: 2, Limit: 10, TotalRecords: 11, TotalPages: 2, HasPrev: true},
+		},
+		{
+			name: "page past the end",
+			args: args{page: 5, limit: 10, total: 20},
+			want: Meta{Page: 5, Limit: 10, TotalRecords: 20, TotalPages: 2, HasPrev: true},
+		},
+		{
+			name: "no limit",
+			args: args{page: 1, limit: 0, total: 25},
+			want: Meta{Page: 1, TotalRecords: 25, TotalPages: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMeta(tt.args.page, tt.args.limit, tt.args.total); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMeta() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
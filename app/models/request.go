@@ -0,0 +1,16 @@
+package models
+
+import "context"
+
+type requestIDCtxKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request id.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request id stored by ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,53 @@
+package models
+
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+
+	PermissionTeamMemberRead        = "team_member:read"
+	PermissionTeamMemberCreate      = "team_member:create"
+	PermissionTeamMemberUpdate      = "team_member:update"
+	PermissionTeamMemberDelete      = "team_member:delete"
+	PermissionTeamMemberRestore     = "team_member:restore"
+	PermissionTeamMemberReadDeleted = "team_member:read_deleted"
+	PermissionAuditLogRead          = "audit_log:read"
+	PermissionMetricsRead           = "metrics:read"
+)
+
+var (
+	// DefaultRolePermissions is seeded into role_permissions when the table is empty, a
+	// permission added here also needs a migration granting it to the seeded databases
+	DefaultRolePermissions = map[string][]string{
+		RoleViewer: {
+			PermissionTeamMemberRead,
+		},
+		RoleEditor: {
+			PermissionTeamMemberRead,
+			PermissionTeamMemberCreate,
+			PermissionTeamMemberUpdate,
+		},
+		RoleAdmin: {
+			PermissionTeamMemberRead,
+			PermissionTeamMemberCreate,
+			PermissionTeamMemberUpdate,
+			PermissionTeamMemberDelete,
+			PermissionTeamMemberRestore,
+			PermissionTeamMemberReadDeleted,
+			PermissionAuditLogRead,
+			PermissionMetricsRead,
+		},
+	}
+)
+
+// RolePermission represents the model for an RolePermissions, one row grants one action to a role
+type RolePermission struct {
+	ID         uint64 `json:"id" gorm:"primaryKey"`
+	Role       string `json:"role" gorm:"not null;uniqueIndex:idx_role_permissions_role_permission"`
+	Permission string `json:"permission" gorm:"not null;uniqueIndex:idx_role_permissions_role_permission"`
+	DefaultModel
+}
+
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestRolePermission_TableName(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   RolePermission
+		want string
+	}{
+		{
+			name: "success",
+			tr:   RolePermission{},
+			want: "role_permissions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.TableName(); got != tt.want {
+				t.Errorf("RolePermission.TableName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
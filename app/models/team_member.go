@@ -1,14 +1,56 @@
 package models
 
+import "time"
+
+// TeamMemberCursorColumns are the sort_by columns a team member list can be paged by cursor on.
+var TeamMemberCursorColumns = map[string]bool{
+	"id":              true,
+	"name":            true,
+	"email":           true,
+	"username_github": true,
+	"created_at":      true,
+	"updated_at":      true,
+}
+
 // TeamMember represents the model for an TeamMembers
 type TeamMember struct {
 	ID             uint64 `json:"id" gorm:"primaryKey"`
 	Name           string `json:"name" gorm:"not null"`
-	UsernameGithub string `json:"username_github" gorm:"not null;uniqueIndex"`
-	Email          string `json:"email" gorm:"not null;uniqueIndex"`
+	UsernameGithub string `json:"username_github" gorm:"not null;uniqueIndex:idx_team_members_username_github_active,where:deleted_at IS NULL"`
+	Email          string `json:"email" gorm:"not null;uniqueIndex:idx_team_members_email_active,where:deleted_at IS NULL"`
+	// Version counts the writes to the team member, it is its ETag.
+	Version uint64 `json:"version" gorm:"not null;default:1"`
 	DefaultModel
 }
 
 func (TeamMember) TableName() string {
 	return "team_members"
 }
+
+// Cursor is the position of the team member in a list sorted by sort then id.
+func (m TeamMember) Cursor(sort Sort, backward bool) Cursor {
+	cursor := Cursor{
+		SortBy:   sort.Column,
+		OrderBy:  OrderByASC,
+		ID:       m.ID,
+		Backward: backward,
+	}
+	if sort.Desc {
+		cursor.OrderBy = OrderByDESC
+	}
+
+	switch sort.Column {
+	case "name":
+		cursor.Value = m.Name
+	case "email":
+		cursor.Value = m.Email
+	case "username_github":
+		cursor.Value = m.UsernameGithub
+	case "created_at":
+		cursor.Value = m.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case "updated_at":
+		cursor.Value = m.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return cursor
+}
@@ -1,6 +1,10 @@
 package models
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+	"time"
+)
 
 func TestTeamMember_TableName(t *testing.T) {
 	tests := []struct {
@@ -22,3 +26,47 @@ func TestTeamMember_TableName(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamMember_Cursor(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.FixedZone("WIB", 7*3600))
+	member := TeamMember{
+		ID:             7,
+		Name:           "adam",
+		UsernameGithub: "adamnasrudin03",
+		Email:          "adam@example.com",
+		DefaultModel: DefaultModel{
+			CreatedAt: createdAt,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		sortBy   string
+		backward bool
+		want     Cursor
+	}{
+		{
+			name:   "sort by id",
+			sortBy: "id",
+			want:   Cursor{SortBy: "id", OrderBy: OrderByASC, ID: 7},
+		},
+		{
+			name:   "sort by email",
+			sortBy: "email",
+			want:   Cursor{SortBy: "email", OrderBy: OrderByASC, Value: "adam@example.com", ID: 7},
+		},
+		{
+			name:     "sort by created_at in utc",
+			sortBy:   "created_at",
+			backward: true,
+			want:     Cursor{SortBy: "created_at", OrderBy: OrderByASC, Value: "2024-01-01T20:04:05.000006Z", ID: 7, Backward: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := member.Cursor(Sort{Column: tt.sortBy}, tt.backward); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TeamMember.Cursor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
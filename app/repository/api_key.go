@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ApiKeyRepository interface {
+	CreateCache(ctx context.Context, key string, data interface{}, ttl time.Duration)
+	GetCache(ctx context.Context, key string, res interface{}) bool
+	GetByHash(ctx context.Context, keyHash string) (*models.ApiKey, error)
+	Create(ctx context.Context, req *models.ApiKey) (*models.ApiKey, error)
+}
+
+type ApiKeyRepo struct {
+	DB     *gorm.DB
+	Cache  driver.RedisClient
+	Cfg    *configs.Configs
+	Logger *logrus.Logger
+}
+
+func NewApiKeyRepository(
+	db *gorm.DB,
+	redis driver.RedisClient,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+) ApiKeyRepository {
+	return &ApiKeyRepo{
+		DB:     db,
+		Cache:  redis,
+		Cfg:    cfg,
+		Logger: logger,
+	}
+}
+
+func (r *ApiKeyRepo) CreateCache(ctx context.Context, key string, data interface{}, ttl time.Duration) {
+	var (
+		opName = "ApiKeyRepository-CreateCache"
+		err    error
+	)
+	if ttl == 0 {
+		ttl = r.Cfg.Redis.DefaultCacheTimeOut
+	}
+
+	err = r.Cache.Set(key, data, ttl)
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return
+	}
+}
+
+func (r *ApiKeyRepo) GetCache(ctx context.Context, key string, res interface{}) bool {
+	var (
+		opName = "ApiKeyRepository-GetCache"
+		err    error
+	)
+
+	data, err := r.Cache.Get(key)
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return false
+	}
+
+	err = json.Unmarshal([]byte(data), &res)
+	if err != nil {
+		r.Logger.Errorf("%v Unmarshal error: %v ", opName, err)
+		return false
+	}
+
+	return true
+}
+
+func (r *ApiKeyRepo) GetByHash(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+	var (
+		opName = "ApiKeyRepository-GetByHash"
+		err    error
+		resp   *models.ApiKey
+	)
+
+	err = r.DB.WithContext(ctx).Where("key_hash = ?", keyHash).First(&resp).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *ApiKeyRepo) Create(ctx context.Context, req *models.ApiKey) (*models.ApiKey, error) {
+	var (
+		opName = "ApiKeyRepository-Create"
+		err    error
+	)
+
+	err = r.DB.WithContext(ctx).Create(req).Error
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository interface {
+	GetList(ctx context.Context, req dto.AuditLogListReq) ([]models.AuditLog, error)
+	Count(ctx context.Context, req dto.AuditLogListReq) (int64, error)
+}
+
+type AuditLogRepo struct {
+	DB     *gorm.DB
+	Cfg    *configs.Configs
+	Logger *logrus.Logger
+}
+
+func NewAuditLogRepository(
+	db *gorm.DB,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+) AuditLogRepository {
+	return &AuditLogRepo{
+		DB:     db,
+		Cfg:    cfg,
+		Logger: logger,
+	}
+}
+
+func (r *AuditLogRepo) GetList(ctx context.Context, req dto.AuditLogListReq) ([]models.AuditLog, error) {
+	var (
+		opName = "AuditLogRepository-GetList"
+		err    error
+		resp   []models.AuditLog
+	)
+
+	db := r.filter(conn(ctx, r.DB), req)
+	if len(req.Selects) > 0 {
+		db = db.Select(models.AuditLogFields.Columns(req.Selects))
+	}
+	if !req.IsNoLimit {
+		db = db.Offset(req.Offset).Limit(req.Limit)
+	}
+
+	sorts := req.Sorts
+	if len(sorts) == 0 {
+		sorts = []models.Sort{{Column: "id", Desc: true}}
+	}
+	db = orderBy(db, sorts)
+
+	err = db.Find(&resp).Error
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *AuditLogRepo) Count(ctx context.Context, req dto.AuditLogListReq) (int64, error) {
+	var (
+		opName = "AuditLogRepository-Count"
+		err    error
+		total  int64
+	)
+
+	err = r.filter(conn(ctx, r.DB), req).Count(&total).Error
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *AuditLogRepo) filter(db *gorm.DB, req dto.AuditLogListReq) *gorm.DB {
+	db = db.Model(&models.AuditLog{})
+	if req.Entity != "" {
+		db = db.Where("entity = ?", req.Entity)
+	}
+	if req.EntityID > 0 {
+		db = db.Where("entity_id = ?", req.EntityID)
+	}
+	if req.ActorID > 0 {
+		db = db.Where("actor_id = ?", req.ActorID)
+	}
+	if req.Action != "" {
+		db = db.Where("action = ?", req.Action)
+	}
+
+	return db
+}
+
+// writeAuditLog stores the audit entry of a mutation with tx, so it is committed
+// or rolled back together with the mutation.
+func writeAuditLog(ctx context.Context, tx *gorm.DB, action, entity string, entityID uint64, before, after interface{}) error {
+	log, err := models.NewAuditLog(ctx, action, entity, entityID, before, after)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(log).Error
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// pgErrorKinds maps the Postgres SQLSTATE codes handled by callers to a models.DBError kind.
+var pgErrorKinds = map[string]string{
+	"23505": models.DBErrUniqueViolation,
+	"23503": models.DBErrForeignKeyViolation,
+	"23502": models.DBErrNotNullViolation,
+	"23514": models.DBErrCheckViolation,
+	"40001": models.DBErrSerializationFailure,
+	"40P01": models.DBErrSerializationFailure, // deadlock detected, safe to retry as well
+}
+
+// constraintFields maps constraint names to the field reported to the client.
+var constraintFields = map[string]string{
+	"idx_team_members_email_active":           "email",
+	"idx_team_members_username_github_active": "username_github",
+}
+
+// translateError turns a Postgres error into a *models.DBError, other errors are returned as is.
+func translateError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	kind, ok := pgErrorKinds[pgErr.Code]
+	if !ok {
+		return err
+	}
+
+	field := constraintFields[pgErr.ConstraintName]
+	if field == "" {
+		field = pgErr.ColumnName
+	}
+
+	return &models.DBError{
+		Kind:       kind,
+		Constraint: pgErr.ConstraintName,
+		Field:      field,
+		Err:        err,
+	}
+}
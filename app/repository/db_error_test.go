@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestTranslateError(t *testing.T) {
+	var (
+		plain     = errors.New("connection reset")
+		unique    = &pgconn.PgError{Code: "23505", ConstraintName: "idx_team_members_email_active"}
+		notNull   = &pgconn.PgError{Code: "23502", ColumnName: "name"}
+		deadlock  = &pgconn.PgError{Code: "40P01"}
+		undefined = &pgconn.PgError{Code: "42P01"}
+	)
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "not a postgres error",
+			err:  plain,
+			want: plain,
+		},
+		{
+			name: "unhandled code",
+			err:  undefined,
+			want: undefined,
+		},
+		{
+			name: "unique violation on a known constraint",
+			err:  fmt.Errorf("create: %w", unique),
+			want: &models.DBError{
+				Kind:       models.DBErrUniqueViolation,
+				Constraint: "idx_team_members_email_active",
+				Field:      "email",
+				Err:        fmt.Errorf("create: %w", unique),
+			},
+		},
+		{
+			name: "not null violation uses the column",
+			err:  notNull,
+			want: &models.DBError{
+				Kind:  models.DBErrNotNullViolation,
+				Field: "name",
+				Err:   notNull,
+			},
+		},
+		{
+			name: "deadlock is a serialization failure",
+			err:  deadlock,
+			want: &models.DBError{
+				Kind: models.DBErrSerializationFailure,
+				Err:  deadlock,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translateError(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("translateError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
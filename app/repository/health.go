@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type HealthRepository interface {
+	PingDB(ctx context.Context) error
+	PingCache(ctx context.Context) error
+}
+
+type HealthRepo struct {
+	DB     *gorm.DB
+	Cache  driver.RedisClient
+	Cfg    *configs.Configs
+	Logger *logrus.Logger
+}
+
+func NewHealthRepository(
+	db *gorm.DB,
+	redis driver.RedisClient,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+) HealthRepository {
+	return &HealthRepo{
+		DB:     db,
+		Cache:  redis,
+		Cfg:    cfg,
+		Logger: logger,
+	}
+}
+
+func (r *HealthRepo) PingDB(ctx context.Context) error {
+	var (
+		opName = "HealthRepository-PingDB"
+		err    error
+	)
+
+	sqlDB, err := r.DB.DB()
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return err
+	}
+
+	err = sqlDB.PingContext(ctx)
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *HealthRepo) PingCache(ctx context.Context) error {
+	var (
+		opName = "HealthRepository-PingCache"
+		errCh  = make(chan error, 1)
+	)
+
+	// the redis client has no context support, so bound the ping by ctx here
+	go func() {
+		errCh <- r.Cache.Ping()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			r.Logger.Errorf("%v error: %v ", opName, err)
+		}
+		return err
+	case <-ctx.Done():
+		r.Logger.Errorf("%v error: %v ", opName, ctx.Err())
+		return ctx.Err()
+	}
+}
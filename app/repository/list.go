@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// keyset reads the rows after cursor, or before it when the cursor is backward, in the
+// order of its column then id. One row more than limit is read so the caller knows
+// whether another page follows, backward rows come nearest to the cursor first.
+func keyset(db *gorm.DB, cursor models.Cursor, limit int) (*gorm.DB, error) {
+	arg, err := cursor.Arg()
+	if err != nil {
+		return nil, err
+	}
+
+	isDesc := cursor.OrderBy == models.OrderByDESC
+	if cursor.Backward {
+		isDesc = !isDesc
+	}
+
+	compare, direction := ">", models.OrderByASC
+	if isDesc {
+		compare, direction = "<", models.OrderByDESC
+	}
+
+	if cursor.SortBy == "id" {
+		db = db.Where("id "+compare+" ?", arg)
+	} else {
+		db = db.Where(fmt.Sprintf("(%s, id) %s (?, ?)", cursor.SortBy, compare), arg, cursor.ID).
+			Order(cursor.SortBy + " " + direction)
+	}
+
+	return db.Order("id " + direction).Limit(limit + 1), nil
+}
+
+// orderBy sorts db by sorts, columns come from models.Fields, then by id in the direction
+// of the first sort so pages and the cursors built from them stay stable.
+func orderBy(db *gorm.DB, sorts []models.Sort) *gorm.DB {
+	tieBreak := models.Sort{Column: "id"}
+	for i, sort := range sorts {
+		db = db.Order(sort.String())
+		if sort.Column == tieBreak.Column {
+			return db
+		}
+		if i == 0 {
+			tieBreak.Desc = sort.Desc
+		}
+	}
+
+	return db.Order(tieBreak.String())
+}
+
+// likeEscaper escapes the wildcards of a like filter value, backslash is the Postgres escape.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// filterExpr translates a models.Filter into a where expression, columns come from models.Fields
+// and every value is a bind variable.
+func filterExpr(filter models.Filter) clause.Expression {
+	exprs := make([]clause.Expression, 0, len(filter.Conditions)+len(filter.Groups))
+	for _, condition := range filter.Conditions {
+		exprs = append(exprs, conditionExpr(condition))
+	}
+	for _, group := range filter.Groups {
+		if !group.IsEmpty() {
+			exprs = append(exprs, filterExpr(group))
+		}
+	}
+
+	if filter.Or {
+		return clause.Or(exprs...)
+	}
+	return clause.And(exprs...)
+}
+
+func conditionExpr(condition models.Condition) clause.Expression {
+	column := clause.Column{Name: condition.Column}
+	switch condition.Operator {
+	case models.FilterNe:
+		return clause.Neq{Column: column, Value: condition.Value}
+	case models.FilterGt:
+		return clause.Gt{Column: column, Value: condition.Value}
+	case models.FilterGte:
+		return clause.Gte{Column: column, Value: condition.Value}
+	case models.FilterLt:
+		return clause.Lt{Column: column, Value: condition.Value}
+	case models.FilterLte:
+		return clause.Lte{Column: column, Value: condition.Value}
+	case models.FilterLike:
+		return clause.Like{Column: column, Value: likeContains(condition.Value)}
+	case models.FilterIlike:
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{column, likeContains(condition.Value)}}
+	case models.FilterIn:
+		values, _ := condition.Value.([]interface{})
+		return clause.IN{Column: column, Values: values}
+	case models.FilterNull:
+		if isNull, _ := condition.Value.(bool); isNull {
+			return clause.Eq{Column: column, Value: nil}
+		}
+		return clause.Neq{Column: column, Value: nil}
+	default:
+		return clause.Eq{Column: column, Value: condition.Value}
+	}
+}
+
+func likeContains(value interface{}) string {
+	return "%" + likeEscaper.Replace(fmt.Sprint(value)) + "%"
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+func TestKeyset(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   models.Cursor
+		wantSQL  string
+		wantVars []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "forward by id",
+			cursor:   models.Cursor{SortBy: "id", OrderBy: models.OrderByASC, ID: 7},
+			wantSQL:  `SELECT * FROM "team_members" WHERE id > $1 AND "team_members"."deleted_at" IS NULL ORDER BY id ASC LIMIT $2`,
+			wantVars: []interface{}{uint64(7), 3},
+		},
+		{
+			name:     "backward by id",
+			cursor:   models.Cursor{SortBy: "id", OrderBy: models.OrderByASC, ID: 7, Backward: true},
+			wantSQL:  `SELECT * FROM "team_members" WHERE id < $1 AND "team_members"."deleted_at" IS NULL ORDER BY id DESC LIMIT $2`,
+			wantVars: []interface{}{uint64(7), 3},
+		},
+		{
+			name:     "forward by name descending",
+			cursor:   models.Cursor{SortBy: "name", OrderBy: models.OrderByDESC, Value: "adam", ID: 7},
+			wantSQL:  `SELECT * FROM "team_members" WHERE (name, id) < ($1, $2) AND "team_members"."deleted_at" IS NULL ORDER BY name DESC,id DESC LIMIT $3`,
+			wantVars: []interface{}{"adam", uint64(7), 3},
+		},
+		{
+			name:     "backward by created_at descending",
+			cursor:   models.Cursor{SortBy: "created_at", OrderBy: models.OrderByDESC, Value: "2024-01-01T20:04:05Z", ID: 7, Backward: true},
+			wantSQL:  `SELECT * FROM "team_members" WHERE (created_at, id) > ($1, $2) AND "team_members"."deleted_at" IS NULL ORDER BY created_at ASC,id ASC LIMIT $3`,
+			wantVars: []interface{}{time.Date(2024, 1, 1, 20, 4, 5, 0, time.UTC), uint64(7), 3},
+		},
+		{
+			name:    "invalid value",
+			cursor:  models.Cursor{SortBy: "created_at", Value: "yesterday", ID: 7},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := keyset(newDryRunDB(t).Model(&models.TeamMember{}), tt.cursor, 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("keyset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			stmt := db.Find(&[]models.TeamMember{}).Statement
+			if got := stmt.SQL.String(); got != tt.wantSQL {
+				t.Errorf("keyset() sql = %v, want %v", got, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(stmt.Vars, tt.wantVars) {
+				t.Errorf("keyset() vars = %v, want %v", stmt.Vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		sorts   []models.Sort
+		wantSQL string
+	}{
+		{
+			name:    "unsorted by id",
+			wantSQL: `SELECT * FROM "team_members" WHERE "team_members"."deleted_at" IS NULL ORDER BY id ASC`,
+		},
+		{
+			name:    "ties broken in the first direction",
+			sorts:   []models.Sort{{Column: "created_at", Desc: true}, {Column: "name"}},
+			wantSQL: `SELECT * FROM "team_members" WHERE "team_members"."deleted_at" IS NULL ORDER BY created_at DESC,name ASC,id DESC`,
+		},
+		{
+			name:    "sorted by id",
+			sorts:   []models.Sort{{Column: "name"}, {Column: "id", Desc: true}},
+			wantSQL: `SELECT * FROM "team_members" WHERE "team_members"."deleted_at" IS NULL ORDER BY name ASC,id DESC`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := orderBy(newDryRunDB(t).Model(&models.TeamMember{}), tt.sorts)
+
+			stmt := db.Find(&[]models.TeamMember{}).Statement
+			if got := stmt.SQL.String(); got != tt.wantSQL {
+				t.Errorf("orderBy() sql = %v, want %v", got, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestFilterExpr(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filter   models.Filter
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name: "all conditions hold",
+			filter: models.Filter{
+				Conditions: []models.Condition{
+					{Column: "name", Operator: models.FilterIlike, Value: "50%_a"},
+					{Column: "created_at", Operator: models.FilterGte, Value: createdAt},
+					{Column: "created_by", Operator: models.FilterNe, Value: uint64(3)},
+					{Column: "id", Operator: models.FilterIn, Value: []interface{}{uint64(1), uint64(2)}},
+					{Column: "email", Operator: models.FilterLike, Value: "example"},
+					{Column: "updated_at", Operator: models.FilterLt, Value: createdAt},
+				},
+			},
+			wantSQL: `SELECT * FROM "team_members" WHERE ("name" ILIKE $1 AND "created_at" >= $2 AND "created_by" <> $3 AND "id" IN ($4,$5) AND "email" LIKE $6 AND "updated_at" < $7) AND "team_members"."deleted_at" IS NULL`,
+			wantVars: []interface{}{
+				`%50\%\_a%`, createdAt, uint64(3), uint64(1), uint64(2), "%example%", createdAt,
+			},
+		},
+		{
+			name: "or group with null checks",
+			filter: models.Filter{
+				Conditions: []models.Condition{
+					{Column: "created_by", Operator: models.FilterEq, Value: uint64(3)},
+				},
+				Groups: []models.Filter{
+					{
+						Or: true,
+						Conditions: []models.Condition{
+							{Column: "deleted_by", Operator: models.FilterNull, Value: true},
+							{Column: "updated_at", Operator: models.FilterNull, Value: false},
+							{Column: "updated_by", Operator: models.FilterGt, Value: uint64(1)},
+							{Column: "updated_by", Operator: models.FilterLte, Value: uint64(9)},
+						},
+					},
+				},
+			},
+			wantSQL:  `SELECT * FROM "team_members" WHERE ("created_by" = $1 AND ("deleted_by" IS NULL OR "updated_at" IS NOT NULL OR "updated_by" > $2 OR "updated_by" <= $3)) AND "team_members"."deleted_at" IS NULL`,
+			wantVars: []interface{}{uint64(3), uint64(1), uint64(9)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newDryRunDB(t).Model(&models.TeamMember{}).Where(filterExpr(tt.filter))
+
+			stmt := db.Find(&[]models.TeamMember{}).Statement
+			if got := stmt.SQL.String(); got != tt.wantSQL {
+				t.Errorf("filterExpr() sql = %v, want %v", got, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(stmt.Vars, tt.wantVars) {
+				t.Errorf("filterExpr() vars = %v, want %v", stmt.Vars, tt.wantVars)
+			}
+		})
+	}
+}
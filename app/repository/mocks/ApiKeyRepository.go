@@ -0,0 +1,114 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ApiKeyRepository is an autogenerated mock type for the ApiKeyRepository type
+type ApiKeyRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *ApiKeyRepository) Create(ctx context.Context, req *models.ApiKey) (*models.ApiKey, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ApiKey) (*models.ApiKey, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ApiKey) *models.ApiKey); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ApiKey) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCache provides a mock function with given fields: ctx, key, data, ttl
+func (_m *ApiKeyRepository) CreateCache(ctx context.Context, key string, data interface{}, ttl time.Duration) {
+	_m.Called(ctx, key, data, ttl)
+}
+
+// GetByHash provides a mock function with given fields: ctx, keyHash
+func (_m *ApiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByHash")
+	}
+
+	var r0 *models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ApiKey, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ApiKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCache provides a mock function with given fields: ctx, key, res
+func (_m *ApiKeyRepository) GetCache(ctx context.Context, key string, res interface{}) bool {
+	ret := _m.Called(ctx, key, res)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCache")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) bool); ok {
+		r0 = rf(ctx, key, res)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewApiKeyRepository creates a new instance of ApiKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewApiKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ApiKeyRepository {
+	mock := &ApiKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
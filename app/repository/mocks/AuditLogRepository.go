@@ -0,0 +1,89 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// AuditLogRepository is an autogenerated mock type for the AuditLogRepository type
+type AuditLogRepository struct {
+	mock.Mock
+}
+
+// Count provides a mock function with given fields: ctx, req
+func (_m *AuditLogRepository) Count(ctx context.Context, req dto.AuditLogListReq) (int64, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AuditLogListReq) (int64, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AuditLogListReq) int64); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.AuditLogListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetList provides a mock function with given fields: ctx, req
+func (_m *AuditLogRepository) GetList(ctx context.Context, req dto.AuditLogListReq) ([]models.AuditLog, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 []models.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AuditLogListReq) ([]models.AuditLog, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AuditLogListReq) []models.AuditLog); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.AuditLogListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAuditLogRepository creates a new instance of AuditLogRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditLogRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditLogRepository {
+	mock := &AuditLogRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -9,8 +9,6 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/adamnasrudin03/go-skeleton-mux/app/models"
-
-	time "time"
 )
 
 // TeamMemberRepository is an autogenerated mock type for the TeamMemberRepository type
@@ -18,6 +16,34 @@ type TeamMemberRepository struct {
 	mock.Mock
 }
 
+// Count provides a mock function with given fields: ctx, req
+func (_m *TeamMemberRepository) Count(ctx context.Context, req dto.TeamMemberListReq) (int64, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberListReq) (int64, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberListReq) int64); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TeamMemberListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, req
 func (_m *TeamMemberRepository) Create(ctx context.Context, req *models.TeamMember) (*models.TeamMember, error) {
 	ret := _m.Called(ctx, req)
@@ -48,11 +74,6 @@ func (_m *TeamMemberRepository) Create(ctx context.Context, req *models.TeamMemb
 	return r0, r1
 }
 
-// CreateCache provides a mock function with given fields: ctx, key, data, ttl
-func (_m *TeamMemberRepository) CreateCache(ctx context.Context, key string, data interface{}, ttl time.Duration) {
-	_m.Called(ctx, key, data, ttl)
-}
-
 // Delete provides a mock function with given fields: ctx, req
 func (_m *TeamMemberRepository) Delete(ctx context.Context, req *models.TeamMember) error {
 	ret := _m.Called(ctx, req)
@@ -71,27 +92,32 @@ func (_m *TeamMemberRepository) Delete(ctx context.Context, req *models.TeamMemb
 	return r0
 }
 
-// DeleteCache provides a mock function with given fields: ctx, key
-func (_m *TeamMemberRepository) DeleteCache(ctx context.Context, key string) {
-	_m.Called(ctx, key)
-}
-
-// GetCache provides a mock function with given fields: ctx, key, res
-func (_m *TeamMemberRepository) GetCache(ctx context.Context, key string, res interface{}) bool {
-	ret := _m.Called(ctx, key, res)
+// EstimateCount provides a mock function with given fields: ctx
+func (_m *TeamMemberRepository) EstimateCount(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCache")
+		panic("no return value specified for EstimateCount")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) bool); ok {
-		r0 = rf(ctx, key, res)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetDetail provides a mock function with given fields: ctx, req
@@ -154,6 +180,24 @@ func (_m *TeamMemberRepository) GetList(ctx context.Context, req dto.TeamMemberL
 	return r0, r1
 }
 
+// Restore provides a mock function with given fields: ctx, req
+func (_m *TeamMemberRepository) Restore(ctx context.Context, req *models.TeamMember) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TeamMember) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, req
 func (_m *TeamMemberRepository) Update(ctx context.Context, req *models.TeamMember) error {
 	ret := _m.Called(ctx, req)
@@ -172,6 +216,31 @@ func (_m *TeamMemberRepository) Update(ctx context.Context, req *models.TeamMemb
 	return r0
 }
 
+// UpdateFields provides a mock function with given fields: ctx, req, columns
+func (_m *TeamMemberRepository) UpdateFields(ctx context.Context, req *models.TeamMember, columns ...string) error {
+	_va := make([]interface{}, len(columns))
+	for _i := range columns {
+		_va[_i] = columns[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, req)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFields")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TeamMember, ...string) error); ok {
+		r0 = rf(ctx, req, columns...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTeamMemberRepository creates a new instance of TeamMemberRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTeamMemberRepository(t interface {
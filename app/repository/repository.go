@@ -2,5 +2,10 @@ package repository
 
 // Repositories all repo object injected here
 type Repositories struct {
+	ApiKey     ApiKeyRepository
+	AuditLog   AuditLogRepository
+	Health     HealthRepository
+	Role       RoleRepository
 	TeamMember TeamMemberRepository
+	Tx         TxManager
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	CreateCache(ctx context.Context, key string, data interface{}, ttl time.Duration)
+	GetCache(ctx context.Context, key string, res interface{}) bool
+	GetPermissions(ctx context.Context, role string) ([]string, error)
+}
+
+type RoleRepo struct {
+	DB     *gorm.DB
+	Cache  driver.RedisClient
+	Cfg    *configs.Configs
+	Logger *logrus.Logger
+}
+
+func NewRoleRepository(
+	db *gorm.DB,
+	redis driver.RedisClient,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+) RoleRepository {
+	return &RoleRepo{
+		DB:     db,
+		Cache:  redis,
+		Cfg:    cfg,
+		Logger: logger,
+	}
+}
+
+func (r *RoleRepo) CreateCache(ctx context.Context, key string, data interface{}, ttl time.Duration) {
+	var (
+		opName = "RoleRepository-CreateCache"
+		err    error
+	)
+	if ttl == 0 {
+		ttl = r.Cfg.Redis.DefaultCacheTimeOut
+	}
+
+	err = r.Cache.Set(key, data, ttl)
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return
+	}
+}
+
+func (r *RoleRepo) GetCache(ctx context.Context, key string, res interface{}) bool {
+	var (
+		opName = "RoleRepository-GetCache"
+		err    error
+	)
+
+	data, err := r.Cache.Get(key)
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return false
+	}
+
+	err = json.Unmarshal([]byte(data), &res)
+	if err != nil {
+		r.Logger.Errorf("%v Unmarshal error: %v ", opName, err)
+		return false
+	}
+
+	return true
+}
+
+func (r *RoleRepo) GetPermissions(ctx context.Context, role string) ([]string, error) {
+	var (
+		opName = "RoleRepository-GetPermissions"
+		err    error
+		resp   []string
+	)
+
+	err = r.DB.WithContext(ctx).Model(&models.RolePermission{}).
+		Where("role = ?", role).
+		Order("permission ASC").
+		Pluck("permission", &resp).Error
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// searchSupport tells which search indexes of team_members the database has,
+// both are created by the migration and missing when it could not create them.
+type searchSupport struct {
+	FullText bool `gorm:"column:full_text"`
+	Trigram  bool `gorm:"column:trigram"`
+}
+
+func (s searchSupport) complete() bool {
+	return s.FullText && s.Trigram
+}
+
+// searchRecheckInterval is how long a database missing a search index is trusted before it
+// is read again, so the indexes of a migration run after the process started are picked up.
+const searchRecheckInterval = time.Minute
+
+// searchDetector reads the searchSupport, full support is kept for the life of the process
+// since the migrations never drop the indexes, partial support is read again once stale.
+type searchDetector struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+	support   searchSupport
+}
+
+func (d *searchDetector) get(ctx context.Context, db *gorm.DB) (searchSupport, error) {
+	return d.load(time.Now(), func() (searchSupport, error) {
+		var support searchSupport
+		err := db.WithContext(ctx).Raw(`SELECT
+		EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'team_members' AND column_name = 'search_vector') AS full_text,
+		EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm') AS trigram`).Scan(&support).Error
+		return support, err
+	})
+}
+
+func (d *searchDetector) load(now time.Time, read func() (searchSupport, error)) (searchSupport, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.checkedAt.IsZero() && (d.support.complete() || now.Sub(d.checkedAt) < searchRecheckInterval) {
+		return d.support, nil
+	}
+
+	support, err := read()
+	if err != nil {
+		return searchSupport{}, err
+	}
+
+	d.checkedAt, d.support = now, support
+	return support, nil
+}
+
+// searchWhere matches search against the email with LIKE, plus the full text vector of
+// name, username_github and email, plus the trigram similarity of name and username_github
+// when the database supports them.
+func searchWhere(db *gorm.DB, support searchSupport, search string) *gorm.DB {
+	like := "%" + likeEscaper.Replace(search) + "%"
+	if !support.FullText {
+		return db.Where("email LIKE ?", like)
+	}
+
+	if !support.Trigram {
+		return db.Where("search_vector @@ plainto_tsquery('simple', ?) OR email LIKE ?", search, like)
+	}
+
+	return db.Where("search_vector @@ plainto_tsquery('simple', ?) OR name % ? OR username_github % ? OR email LIKE ?",
+		search, search, search, like)
+}
+
+// searchRank orders the best matches of search first, LIKE matches have no relevance.
+func searchRank(db *gorm.DB, support searchSupport, search string) *gorm.DB {
+	if !support.FullText {
+		return db
+	}
+
+	rank := clause.Expr{SQL: "ts_rank(search_vector, plainto_tsquery('simple', ?)) DESC", Vars: []interface{}{search}}
+	if support.Trigram {
+		rank = clause.Expr{
+			SQL:  "GREATEST(ts_rank(search_vector, plainto_tsquery('simple', ?)), similarity(name, ?), similarity(username_github, ?)) DESC",
+			Vars: []interface{}{search, search, search},
+		}
+	}
+
+	return db.Order(clause.OrderBy{Expression: rank})
+}
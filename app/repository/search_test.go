@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name     string
+		support  searchSupport
+		search   string
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:     "like fallback",
+			search:   "adam_",
+			wantSQL:  `SELECT * FROM "team_members" WHERE email LIKE $1 AND "team_members"."deleted_at" IS NULL`,
+			wantVars: []interface{}{`%adam\_%`},
+		},
+		{
+			name:     "full text",
+			support:  searchSupport{FullText: true},
+			search:   "adam",
+			wantSQL:  `SELECT * FROM "team_members" WHERE (search_vector @@ plainto_tsquery('simple', $1) OR email LIKE $2) AND "team_members"."deleted_at" IS NULL ORDER BY ts_rank(search_vector, plainto_tsquery('simple', $3)) DESC`,
+			wantVars: []interface{}{"adam", "%adam%", "adam"},
+		},
+		{
+			name:     "full text and trigram",
+			support:  searchSupport{FullText: true, Trigram: true},
+			search:   "adm",
+			wantSQL:  `SELECT * FROM "team_members" WHERE (search_vector @@ plainto_tsquery('simple', $1) OR name % $2 OR username_github % $3 OR email LIKE $4) AND "team_members"."deleted_at" IS NULL ORDER BY GREATEST(ts_rank(search_vector, plainto_tsquery('simple', $5)), similarity(name, $6), similarity(username_github, $7)) DESC`,
+			wantVars: []interface{}{"adm", "adm", "adm", "%adm%", "adm", "adm", "adm"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newDryRunDB(t).Model(&models.TeamMember{})
+			db = searchRank(searchWhere(db, tt.support, tt.search), tt.support, tt.search)
+
+			stmt := db.Find(&[]models.TeamMember{}).Statement
+			if got := stmt.SQL.String(); got != tt.wantSQL {
+				t.Errorf("search sql = %v, want %v", got, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(stmt.Vars, tt.wantVars) {
+				t.Errorf("search vars = %v, want %v", stmt.Vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestSearchDetector_Load(t *testing.T) {
+	var (
+		start   = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		none    = searchSupport{}
+		partial = searchSupport{FullText: true}
+		full    = searchSupport{FullText: true, Trigram: true}
+		errRead = errors.New("db is down")
+	)
+	type step struct {
+		after   time.Duration
+		read    searchSupport
+		readErr error
+		want    searchSupport
+		wantErr bool
+		// wantRead is set when the database is expected to be read
+		wantRead bool
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "full support is kept",
+			steps: []step{
+				{read: full, want: full, wantRead: true},
+				{after: time.Hour, want: full},
+			},
+		},
+		{
+			name: "missing support is read again once stale",
+			steps: []step{
+				{read: none, want: none, wantRead: true},
+				{after: time.Second, want: none},
+				{after: searchRecheckInterval, read: partial, want: partial, wantRead: true},
+				{after: searchRecheckInterval + time.Second, want: partial},
+				{after: 2 * searchRecheckInterval, read: full, want: full, wantRead: true},
+				{after: time.Hour, want: full},
+			},
+		},
+		{
+			name: "failed read is not kept",
+			steps: []step{
+				{readErr: errRead, wantErr: true, wantRead: true},
+				{read: full, want: full, wantRead: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d searchDetector
+			for i, s := range tt.steps {
+				read := false
+				got, err := d.load(start.Add(s.after), func() (searchSupport, error) {
+					read = true
+					return s.read, s.readErr
+				})
+				if (err != nil) != s.wantErr {
+					t.Fatalf("step %d searchDetector.load() error = %v, wantErr %v", i, err, s.wantErr)
+				}
+				if got != s.want {
+					t.Errorf("step %d searchDetector.load() = %+v, want %+v", i, got, s.want)
+				}
+				if read != s.wantRead {
+					t.Errorf("step %d searchDetector.load() read = %v, want %v", i, read, s.wantRead)
+				}
+			}
+		})
+	}
+}
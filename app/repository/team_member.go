@@ -2,99 +2,52 @@ package repository
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"time"
 
 	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
-	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrTeamMemberNotFound is returned by a write to a team member that is deleted by then.
+var ErrTeamMemberNotFound = errors.New("team member not found")
+
 type TeamMemberRepository interface {
-	CreateCache(ctx context.Context, key string, data interface{}, ttl time.Duration)
-	DeleteCache(ctx context.Context, key string)
-	GetCache(ctx context.Context, key string, res interface{}) bool
 	GetDetail(ctx context.Context, req dto.TeamMemberDetailReq) (*models.TeamMember, error)
 	Create(ctx context.Context, req *models.TeamMember) (*models.TeamMember, error)
 	Update(ctx context.Context, req *models.TeamMember) error
+	UpdateFields(ctx context.Context, req *models.TeamMember, columns ...string) error
 	Delete(ctx context.Context, req *models.TeamMember) error
+	Restore(ctx context.Context, req *models.TeamMember) error
 	GetList(ctx context.Context, req dto.TeamMemberListReq) ([]models.TeamMember, error)
+	Count(ctx context.Context, req dto.TeamMemberListReq) (int64, error)
+	EstimateCount(ctx context.Context) (int64, error)
 }
 
 type TeamMemberRepo struct {
 	DB     *gorm.DB
-	Cache  driver.RedisClient
 	Cfg    *configs.Configs
 	Logger *logrus.Logger
+
+	search searchDetector
 }
 
 func NewTeamMemberRepository(
 	db *gorm.DB,
-	redis driver.RedisClient,
 	cfg *configs.Configs,
 	logger *logrus.Logger,
 ) TeamMemberRepository {
 	return &TeamMemberRepo{
 		DB:     db,
-		Cache:  redis,
 		Cfg:    cfg,
 		Logger: logger,
 	}
 }
 
-func (r *TeamMemberRepo) CreateCache(ctx context.Context, key string, data interface{}, ttl time.Duration) {
-	var (
-		opName = "TeamMemberRepository-CreateCache"
-		err    error
-	)
-	if ttl == 0 {
-		ttl = r.Cfg.Redis.DefaultCacheTimeOut
-	}
-
-	err = r.Cache.Set(key, data, ttl)
-	if err != nil {
-		r.Logger.Errorf("%v error: %v ", opName, err)
-		return
-	}
-}
-
-func (r *TeamMemberRepo) DeleteCache(ctx context.Context, key string) {
-	var (
-		opName = "TeamMemberRepository-DeleteCache"
-		err    error
-	)
-	err = r.Cache.Del(key)
-	if err != nil {
-		r.Logger.Errorf("%v error: %v ", opName, err)
-		return
-	}
-}
-
-func (r *TeamMemberRepo) GetCache(ctx context.Context, key string, res interface{}) bool {
-	var (
-		opName = "TeamMemberRepository-GetCache"
-		err    error
-	)
-
-	data, err := r.Cache.Get(key)
-	if err != nil {
-		r.Logger.Errorf("%v error: %v ", opName, err)
-		return false
-	}
-
-	err = json.Unmarshal([]byte(data), &res)
-	if err != nil {
-		r.Logger.Errorf("%v Unmarshal error: %v ", opName, err)
-		return false
-	}
-
-	return true
-}
-
 func (r *TeamMemberRepo) GetDetail(ctx context.Context, req dto.TeamMemberDetailReq) (*models.TeamMember, error) {
 	var (
 		opName = "TeamMemberRepository-GetDetail"
@@ -106,7 +59,13 @@ func (r *TeamMemberRepo) GetDetail(ctx context.Context, req dto.TeamMemberDetail
 		column = req.CustomColumn
 	}
 
-	db := r.DB.WithContext(ctx).Model(&models.TeamMember{}).Select(column)
+	db := conn(ctx, r.DB).Model(&models.TeamMember{}).Select(column)
+	if req.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	if req.ForUpdate {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
 
 	if req.ID > 0 {
 		db = db.Where("id = ?", req.ID)
@@ -139,10 +98,17 @@ func (r *TeamMemberRepo) Create(ctx context.Context, req *models.TeamMember) (*m
 		opName = "TeamMemberRepository-Create"
 		err    error
 	)
-	err = r.DB.WithContext(ctx).Create(req).Error
+	err = conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(req).Error
+		if err != nil {
+			return err
+		}
+
+		return writeAuditLog(ctx, tx, models.AuditActionCreate, models.AuditEntityTeamMember, req.ID, nil, req)
+	})
 	if err != nil {
 		r.Logger.Errorf("%v error: %v ", opName, err)
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return req, nil
@@ -153,10 +119,30 @@ func (r *TeamMemberRepo) Update(ctx context.Context, req *models.TeamMember) err
 		opName = "TeamMemberRepository-Update"
 		err    error
 	)
-	err = r.DB.WithContext(ctx).Model(&models.TeamMember{}).Where("id = ?", req.ID).Updates(req).Error
+	err = r.mutate(ctx, models.AuditActionUpdate, req.ID, req.Version, func(tx *gorm.DB) error {
+		return tx.Model(&models.TeamMember{}).Where("id = ?", req.ID).Omit("version").Updates(req).Error
+	})
 	if err != nil {
 		r.Logger.Errorf("%v error: %v ", opName, err)
-		return err
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// UpdateFields writes exactly the columns of req, zero values included, unlike Update
+// which skips them.
+func (r *TeamMemberRepo) UpdateFields(ctx context.Context, req *models.TeamMember, columns ...string) error {
+	var (
+		opName = "TeamMemberRepository-UpdateFields"
+		err    error
+	)
+	err = r.mutate(ctx, models.AuditActionUpdate, req.ID, req.Version, func(tx *gorm.DB) error {
+		return tx.Model(&models.TeamMember{}).Where("id = ?", req.ID).Select(columns).Updates(req).Error
+	})
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return translateError(err)
 	}
 
 	return nil
@@ -168,40 +154,115 @@ func (r *TeamMemberRepo) Delete(ctx context.Context, req *models.TeamMember) err
 		err    error
 	)
 
-	err = r.DB.WithContext(ctx).Where("id = ?", req.ID).Delete(&models.TeamMember{}).Error
+	// soft delete through an update so deleted_by is written with deleted_at
+	err = r.mutate(ctx, models.AuditActionDelete, req.ID, req.Version, func(tx *gorm.DB) error {
+		res := tx.Model(&models.TeamMember{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+			"deleted_at": time.Now(),
+			"deleted_by": req.DeletedBy,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		// the row is locked by mutate even when deleted, only an active row may be deleted
+		if res.RowsAffected == 0 {
+			return ErrTeamMemberNotFound
+		}
+
+		return nil
+	})
 	if err != nil {
 		r.Logger.Errorf("%v error: %v ", opName, err)
-		return err
+		return translateError(err)
 	}
 
 	return nil
 }
 
+func (r *TeamMemberRepo) Restore(ctx context.Context, req *models.TeamMember) error {
+	var (
+		opName = "TeamMemberRepository-Restore"
+		err    error
+	)
+
+	err = r.mutate(ctx, models.AuditActionRestore, req.ID, req.Version, func(tx *gorm.DB) error {
+		return tx.Unscoped().Model(&models.TeamMember{}).Where("id = ? AND deleted_at IS NOT NULL", req.ID).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"deleted_by": 0,
+		}).Error
+	})
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// mutate runs fn in a transaction between reading the row before and after it,
+// and records the difference in audit_logs within the same transaction. The row
+// must be at version unless it is 0, its version is bumped after fn.
+func (r *TeamMemberRepo) mutate(ctx context.Context, action string, id uint64, version uint64, fn func(tx *gorm.DB) error) error {
+	return conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		var before, after models.TeamMember
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&before).Error
+		if err != nil {
+			return err
+		}
+		if version > 0 && before.Version != version {
+			return models.NewVersionMismatch(id, before.Version, version)
+		}
+
+		err = fn(tx)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Model(&models.TeamMember{}).Where("id = ?", id).UpdateColumn("version", gorm.Expr("version + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("id = ?", id).Take(&after).Error
+		if err != nil {
+			return err
+		}
+
+		return writeAuditLog(ctx, tx, action, models.AuditEntityTeamMember, id, &before, &after)
+	})
+}
+
+// GetList reads a page of team members by offset, or limit+1 rows from req.Keyset when it is set.
 func (r *TeamMemberRepo) GetList(ctx context.Context, req dto.TeamMemberListReq) ([]models.TeamMember, error) {
 	var (
 		opName = "TeamMemberRepository-GetList"
 		err    error
 		resp   []models.TeamMember
-		column = "*"
 	)
-	if req.CustomColumns != "" {
-		column = req.CustomColumns
-	}
 
-	db := r.DB.WithContext(ctx).Model(&models.TeamMember{}).Select(column)
-	if req.Search != "" {
-		db = db.Where("email LIKE ?", "%"+req.Search+"%")
+	db := r.filter(ctx, conn(ctx, r.DB), req)
+	if len(req.Selects) > 0 {
+		db = db.Select(models.TeamMemberFields.Columns(req.Selects, req.Sorts...))
 	}
-
 	if !req.IsNotDefaultQuery {
 		req = req.DefaultQuery()
 	}
-	if !req.IsNoLimit {
-		db = db.Offset(int(req.Offset)).Limit(int(req.Limit))
-	}
 
-	if models.IsValidOrderBy[req.OrderBy] && req.SortBy != "" {
-		db = db.Order(req.SortBy + " " + req.OrderBy)
+	if req.Keyset != nil {
+		db, err = keyset(db, *req.Keyset, req.Limit)
+		if err != nil {
+			r.Logger.Errorf("%v keyset error: %v ", opName, err)
+			return nil, err
+		}
+	} else {
+		if !req.IsNoLimit {
+			db = db.Offset(int(req.Offset)).Limit(int(req.Limit))
+		}
+
+		// without a sort, searches list the most relevant members first
+		if req.Search != "" && len(req.Sorts) == 0 {
+			db = searchRank(db, r.searchSupport(ctx), req.Search)
+		}
+		db = orderBy(db, req.Sorts)
 	}
 
 	err = db.Find(&resp).Error
@@ -212,3 +273,66 @@ func (r *TeamMemberRepo) GetList(ctx context.Context, req dto.TeamMemberListReq)
 
 	return resp, nil
 }
+
+func (r *TeamMemberRepo) Count(ctx context.Context, req dto.TeamMemberListReq) (int64, error) {
+	var (
+		opName = "TeamMemberRepository-Count"
+		err    error
+		total  int64
+	)
+
+	err = r.filter(ctx, conn(ctx, r.DB), req).Count(&total).Error
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// EstimateCount returns the planner row estimate of team_members, soft deleted rows
+// included, or -1 when the table has not been analyzed yet.
+func (r *TeamMemberRepo) EstimateCount(ctx context.Context) (int64, error) {
+	var (
+		opName = "TeamMemberRepository-EstimateCount"
+		err    error
+		total  int64
+	)
+
+	err = conn(ctx, r.DB).Raw("SELECT reltuples::bigint FROM pg_class WHERE oid = ?::regclass", models.TeamMember{}.TableName()).Scan(&total).Error
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *TeamMemberRepo) filter(ctx context.Context, db *gorm.DB, req dto.TeamMemberListReq) *gorm.DB {
+	db = db.Model(&models.TeamMember{})
+	if req.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	if req.Search != "" {
+		db = searchWhere(db, r.searchSupport(ctx), req.Search)
+	}
+	if !req.Filter.IsEmpty() {
+		db = db.Where(filterExpr(req.Filter))
+	}
+
+	return db
+}
+
+// searchSupport falls back to searching emails with LIKE while the search indexes can not be detected.
+func (r *TeamMemberRepo) searchSupport(ctx context.Context) searchSupport {
+	var (
+		opName = "TeamMemberRepository-searchSupport"
+	)
+	support, err := r.search.get(ctx, r.DB)
+	if err != nil {
+		r.Logger.Errorf("%v error: %v ", opName, err)
+		return searchSupport{}
+	}
+
+	return support
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ErrNoTransaction is returned by TxManager.Lock outside of WithinTransaction.
+var ErrNoTransaction = errors.New("no transaction in context")
+
+// TxManager runs repository calls in one database transaction carried by the context.
+type TxManager interface {
+	// WithinTransaction runs fn in a transaction, repositories called with the ctx
+	// given to fn join it. Nested calls reuse the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// Lock takes transaction scoped advisory locks on keys, released on commit or rollback.
+	Lock(ctx context.Context, keys ...string) error
+}
+
+type TxMgr struct {
+	DB     *gorm.DB
+	Cfg    *configs.Configs
+	Logger *logrus.Logger
+}
+
+func NewTxManager(
+	db *gorm.DB,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+) TxManager {
+	return &TxMgr{
+		DB:     db,
+		Cfg:    cfg,
+		Logger: logger,
+	}
+}
+
+type txCtxKey struct{}
+
+func (m *TxMgr) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txCtxKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	err := m.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txCtxKey{}, tx))
+	})
+
+	// a commit can fail on serialization, report it like the statements inside fn
+	return translateError(err)
+}
+
+func (m *TxMgr) Lock(ctx context.Context, keys ...string) error {
+	var (
+		opName = "TxManager-Lock"
+		err    error
+	)
+
+	tx, ok := ctx.Value(txCtxKey{}).(*gorm.DB)
+	if !ok {
+		return ErrNoTransaction
+	}
+
+	// always lock in the same order so two transactions can not deadlock
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		err = tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error
+		if err != nil {
+			m.Logger.Errorf("%v error: %v ", opName, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// conn returns the transaction carried by ctx, or db when there is none.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txCtxKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
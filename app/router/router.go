@@ -6,6 +6,8 @@ import (
 
 	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/controller"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
 
 	"github.com/gorilla/mux"
 )
@@ -26,6 +28,8 @@ func NewRoutes(h controller.Controllers) routes {
 			EN: "Welcome this server",
 		})
 	}).Methods("GET")
+	r.HttpServer.Use(middlewares.RequestID)
+	h.Health.Mount(r.HttpServer)
 
 	r.HttpServer.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		err = response_mapper.ErrRouteNotFound()
@@ -34,7 +38,8 @@ func NewRoutes(h controller.Controllers) routes {
 	return r
 }
 
-func (r routes) Run(addr string) error {
+// Run serves the routes until a shutdown signal arrives, then lets the lifecycle manager drain it.
+func (r routes) Run(addr string, lc *lifecycle.Manager) error {
 	server := &http.Server{
 		Addr:         addr,
 		WriteTimeout: time.Second * 15,
@@ -42,5 +47,5 @@ func (r routes) Run(addr string) error {
 		IdleTimeout:  time.Second * 60,
 		Handler:      r.HttpServer,
 	}
-	return server.ListenAndServe()
+	return lc.ListenAndServe(server)
 }
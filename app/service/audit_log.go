@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditLogService interface {
+	GetList(ctx context.Context, req dto.AuditLogListReq) (*models.Pagination, error)
+}
+
+type AuditLogSrv struct {
+	Repo   repository.AuditLogRepository
+	Cfg    *configs.Configs
+	Logger *logrus.Logger
+}
+
+func NewAuditLogService(
+	auditLogRepo repository.AuditLogRepository,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+) AuditLogService {
+	return &AuditLogSrv{
+		Repo:   auditLogRepo,
+		Cfg:    cfg,
+		Logger: logger,
+	}
+}
+
+func (s *AuditLogSrv) GetList(ctx context.Context, req dto.AuditLogListReq) (*models.Pagination, error) {
+	var (
+		opName = "AuditLogService-GetList"
+		err    error
+	)
+	err = req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.Repo.GetList(ctx, req)
+	if err != nil {
+		s.Logger.Errorf("%s, failed get list: %v", opName, err)
+		return nil, response_mapper.ErrDB()
+	}
+
+	total, err := s.Repo.Count(ctx, req)
+	if err != nil {
+		s.Logger.Errorf("%s, failed count: %v", opName, err)
+		return nil, response_mapper.ErrDB()
+	}
+
+	limit := req.Limit
+	if req.IsNoLimit {
+		limit = 0
+	}
+
+	resp, err := listData(data, req.Selects)
+	if err != nil {
+		s.Logger.Errorf("%s, failed select fields: %v", opName, err)
+		return nil, response_mapper.NewError(response_mapper.ErrUnknown, err)
+	}
+
+	return &models.Pagination{
+		Data: resp,
+		Meta: models.NewMeta(req.Page, limit, total),
+	}, nil
+}
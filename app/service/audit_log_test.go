@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditLogSrv_GetList(t *testing.T) {
+	logs := []models.AuditLog{
+		{ID: 2, ActorID: 7, Action: models.AuditActionUpdate, Entity: models.AuditEntityTeamMember, EntityID: 1},
+		{ID: 1, ActorID: 7, Action: models.AuditActionCreate, Entity: models.AuditEntityTeamMember, EntityID: 1},
+	}
+	tests := []struct {
+		name     string
+		req      dto.AuditLogListReq
+		mockFunc func(repo *mocks.AuditLogRepository)
+		want     *models.Pagination
+		wantErr  bool
+	}{
+		{
+			name: "invalid request",
+			req: dto.AuditLogListReq{
+				BasedFilter: models.BasedFilter{OrderBy: "invalid"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed get list",
+			req:  dto.AuditLogListReq{Entity: models.AuditEntityTeamMember},
+			mockFunc: func(repo *mocks.AuditLogRepository) {
+				repo.On("GetList", mock.Anything, mock.Anything).Return(nil, errors.New("invalid")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed count",
+			req:  dto.AuditLogListReq{Entity: models.AuditEntityTeamMember},
+			mockFunc: func(repo *mocks.AuditLogRepository) {
+				repo.On("GetList", mock.Anything, mock.Anything).Return(logs, nil).Once()
+				repo.On("Count", mock.Anything, mock.Anything).Return(int64(0), errors.New("invalid")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			req: dto.AuditLogListReq{
+				BasedFilter: models.BasedFilter{Limit: 2},
+				Entity:      models.AuditEntityTeamMember,
+				ActorID:     7,
+			},
+			mockFunc: func(repo *mocks.AuditLogRepository) {
+				want := dto.AuditLogListReq{
+					BasedFilter: models.BasedFilter{Limit: 2, Page: 1},
+					Entity:      models.AuditEntityTeamMember,
+					ActorID:     7,
+				}
+				repo.On("GetList", mock.Anything, want).Return(logs, nil).Once()
+				repo.On("Count", mock.Anything, want).Return(int64(5), nil).Once()
+			},
+			want: &models.Pagination{
+				Data: logs,
+				Meta: models.Meta{
+					Page:         1,
+					Limit:        2,
+					TotalRecords: 5,
+					TotalPages:   3,
+					HasNext:      true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg  = &configs.Configs{}
+				repo = &mocks.AuditLogRepository{}
+				srv  = NewAuditLogService(repo, cfg, driver.Logger(cfg))
+			)
+			if tt.mockFunc != nil {
+				tt.mockFunc(repo)
+			}
+
+			got, err := srv.GetList(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuditLogSrv.GetList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AuditLogSrv.GetList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
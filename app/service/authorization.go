@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
+	"github.com/sirupsen/logrus"
+)
+
+type AuthorizationService interface {
+	HasPermission(ctx context.Context, role string, permission string) (bool, error)
+}
+
+type AuthorizationSrv struct {
+	Repo      repository.RoleRepository
+	Cfg       *configs.Configs
+	Logger    *logrus.Logger
+	Lifecycle *lifecycle.Manager
+}
+
+func NewAuthorizationService(
+	roleRepo repository.RoleRepository,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+	lc *lifecycle.Manager,
+) AuthorizationService {
+	return &AuthorizationSrv{
+		Repo:      roleRepo,
+		Cfg:       cfg,
+		Logger:    logger,
+		Lifecycle: lc,
+	}
+}
+
+func (s *AuthorizationSrv) HasPermission(ctx context.Context, role string, permission string) (bool, error) {
+	var (
+		opName      = "AuthorizationService-HasPermission"
+		key         = models.KeyCacheRolePermissions(role)
+		permissions []string
+	)
+	if role == "" {
+		return false, nil
+	}
+
+	ok := s.Repo.GetCache(ctx, key, &permissions)
+	if !ok {
+		var err error
+		permissions, err = s.Repo.GetPermissions(ctx, role)
+		if err != nil {
+			s.Logger.Errorf("%s, failed get permissions: %v", opName, err)
+			return false, response_mapper.ErrDB()
+		}
+
+		s.Lifecycle.Go(func() {
+			s.Repo.CreateCache(context.Background(), key, permissions, 0)
+		})
+	}
+
+	for _, v := range permissions {
+		if v == permission {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
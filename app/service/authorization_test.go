@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthorizationSrv_HasPermission(t *testing.T) {
+	type args struct {
+		role       string
+		permission string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func(repo *mocks.RoleRepository, input args)
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "empty role",
+			args: args{role: "", permission: models.PermissionTeamMemberRead},
+			want: false,
+		},
+		{
+			name: "granted from cache",
+			args: args{role: models.RoleViewer, permission: models.PermissionTeamMemberRead},
+			mockFunc: func(repo *mocks.RoleRepository, input args) {
+				repo.On("GetCache", mock.Anything, models.KeyCacheRolePermissions(input.role), mock.Anything).Return(true).Run(func(args mock.Arguments) {
+					target := args.Get(2).(*[]string)
+					*target = []string{models.PermissionTeamMemberRead}
+				}).Once()
+			},
+			want: true,
+		},
+		{
+			name: "failed get permissions",
+			args: args{role: models.RoleEditor, permission: models.PermissionTeamMemberDelete},
+			mockFunc: func(repo *mocks.RoleRepository, input args) {
+				repo.On("GetCache", mock.Anything, models.KeyCacheRolePermissions(input.role), mock.Anything).Return(false).Once()
+				repo.On("GetPermissions", mock.Anything, input.role).Return(nil, errors.New("invalid")).Once()
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "denied from db",
+			args: args{role: models.RoleEditor, permission: models.PermissionTeamMemberDelete},
+			mockFunc: func(repo *mocks.RoleRepository, input args) {
+				permissions := models.DefaultRolePermissions[input.role]
+				repo.On("GetCache", mock.Anything, models.KeyCacheRolePermissions(input.role), mock.Anything).Return(false).Once()
+				repo.On("GetPermissions", mock.Anything, input.role).Return(permissions, nil).Once()
+				repo.On("CreateCache", mock.Anything, models.KeyCacheRolePermissions(input.role), permissions, mock.Anything).Return().Once()
+			},
+			want: false,
+		},
+		{
+			name: "granted from db",
+			args: args{role: models.RoleAdmin, permission: models.PermissionTeamMemberDelete},
+			mockFunc: func(repo *mocks.RoleRepository, input args) {
+				permissions := models.DefaultRolePermissions[input.role]
+				repo.On("GetCache", mock.Anything, models.KeyCacheRolePermissions(input.role), mock.Anything).Return(false).Once()
+				repo.On("GetPermissions", mock.Anything, input.role).Return(permissions, nil).Once()
+				repo.On("CreateCache", mock.Anything, models.KeyCacheRolePermissions(input.role), permissions, mock.Anything).Return().Once()
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg    = &configs.Configs{}
+				logger = driver.Logger(cfg)
+				repo   = &mocks.RoleRepository{}
+				lc     = lifecycle.NewManager(0, 0, logger)
+			)
+			if tt.mockFunc != nil {
+				tt.mockFunc(repo, tt.args)
+			}
+
+			got, err := NewAuthorizationService(repo, cfg, logger, lc).HasPermission(context.Background(), tt.args.role, tt.args.permission)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthorizationSrv.HasPermission() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AuthorizationSrv.HasPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// dbError keeps a *models.DBError so the controller can report the violated
+// constraint, any other repository error is replaced by fallback.
+func dbError(err error, fallback error) error {
+	var dbErr *models.DBError
+	if errors.As(err, &dbErr) {
+		return dbErr
+	}
+
+	return fallback
+}
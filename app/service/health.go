@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	HealthComponentPostgres = "postgres"
+	HealthComponentRedis    = "redis"
+	HealthComponentServer   = "server"
+)
+
+type HealthService interface {
+	Liveness(ctx context.Context) *dto.HealthRes
+	Readiness(ctx context.Context) *dto.HealthRes
+}
+
+type HealthSrv struct {
+	Repo      repository.HealthRepository
+	Cfg       *configs.Configs
+	Logger    *logrus.Logger
+	Lifecycle *lifecycle.Manager
+}
+
+func NewHealthService(
+	healthRepo repository.HealthRepository,
+	cfg *configs.Configs,
+	logger *logrus.Logger,
+	lc *lifecycle.Manager,
+) HealthService {
+	return &HealthSrv{
+		Repo:      healthRepo,
+		Cfg:       cfg,
+		Logger:    logger,
+		Lifecycle: lc,
+	}
+}
+
+// Liveness only reports that the process can serve requests; dependencies are
+// left to Readiness so an outage of Postgres or Redis does not restart pods.
+func (s *HealthSrv) Liveness(ctx context.Context) *dto.HealthRes {
+	return &dto.HealthRes{
+		Status: dto.HealthStatusUp,
+	}
+}
+
+func (s *HealthSrv) Readiness(ctx context.Context) *dto.HealthRes {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		checks = map[string]func(ctx context.Context) error{
+			HealthComponentPostgres: s.Repo.PingDB,
+			HealthComponentRedis:    s.Repo.PingCache,
+		}
+		resp = &dto.HealthRes{
+			Status:     dto.HealthStatusUp,
+			Components: make(map[string]dto.HealthComponentRes, len(checks)+1),
+		}
+	)
+
+	if s.Lifecycle.IsShuttingDown() {
+		resp.Status = dto.HealthStatusDown
+		resp.Components[HealthComponentServer] = dto.HealthComponentRes{
+			Status: dto.HealthStatusDown,
+			Error:  "shutting down",
+		}
+	}
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) error) {
+			defer wg.Done()
+			component := s.check(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Components[name] = component
+			if component.Status != dto.HealthStatusUp {
+				resp.Status = dto.HealthStatusDown
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return resp
+}
+
+func (s *HealthSrv) check(ctx context.Context, check func(ctx context.Context) error) dto.HealthComponentRes {
+	ctx, cancel := context.WithTimeout(ctx, s.Cfg.App.HealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	resp := dto.HealthComponentRes{
+		Status:  dto.HealthStatusUp,
+		Latency: time.Since(start).String(),
+	}
+	if err != nil {
+		resp.Status = dto.HealthStatusDown
+		resp.Error = err.Error()
+	}
+
+	return resp
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthSrv_Liveness(t *testing.T) {
+	var (
+		cfg    = &configs.Configs{}
+		logger = driver.Logger(cfg)
+		repo   = &mocks.HealthRepository{}
+		srv    = NewHealthService(repo, cfg, logger, lifecycle.NewManager(0, 0, logger))
+	)
+
+	want := &dto.HealthRes{Status: dto.HealthStatusUp}
+	if got := srv.Liveness(context.Background()); !reflect.DeepEqual(got, want) {
+		t.Errorf("HealthSrv.Liveness() = %v, want %v", got, want)
+	}
+	repo.AssertNotCalled(t, "PingDB", mock.Anything)
+}
+
+func TestHealthSrv_Readiness(t *testing.T) {
+	tests := []struct {
+		name           string
+		shuttingDown   bool
+		mockFunc       func(repo *mocks.HealthRepository)
+		wantUp         bool
+		wantComponents map[string]string
+	}{
+		{
+			name: "all dependencies up",
+			mockFunc: func(repo *mocks.HealthRepository) {
+				repo.On("PingDB", mock.Anything).Return(nil).Once()
+				repo.On("PingCache", mock.Anything).Return(nil).Once()
+			},
+			wantUp: true,
+			wantComponents: map[string]string{
+				HealthComponentPostgres: dto.HealthStatusUp,
+				HealthComponentRedis:    dto.HealthStatusUp,
+			},
+		},
+		{
+			name: "postgres down",
+			mockFunc: func(repo *mocks.HealthRepository) {
+				repo.On("PingDB", mock.Anything).Return(errors.New("connection refused")).Once()
+				repo.On("PingCache", mock.Anything).Return(nil).Once()
+			},
+			wantUp: false,
+			wantComponents: map[string]string{
+				HealthComponentPostgres: dto.HealthStatusDown,
+				HealthComponentRedis:    dto.HealthStatusUp,
+			},
+		},
+		{
+			name: "redis exceeds timeout",
+			mockFunc: func(repo *mocks.HealthRepository) {
+				repo.On("PingDB", mock.Anything).Return(nil).Once()
+				repo.On("PingCache", mock.Anything).Return(func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}).Once()
+			},
+			wantUp: false,
+			wantComponents: map[string]string{
+				HealthComponentPostgres: dto.HealthStatusUp,
+				HealthComponentRedis:    dto.HealthStatusDown,
+			},
+		},
+		{
+			name:         "shutting down",
+			shuttingDown: true,
+			mockFunc: func(repo *mocks.HealthRepository) {
+				repo.On("PingDB", mock.Anything).Return(nil).Once()
+				repo.On("PingCache", mock.Anything).Return(nil).Once()
+			},
+			wantUp: false,
+			wantComponents: map[string]string{
+				HealthComponentPostgres: dto.HealthStatusUp,
+				HealthComponentRedis:    dto.HealthStatusUp,
+				HealthComponentServer:   dto.HealthStatusDown,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				cfg    = &configs.Configs{App: configs.AppConfig{HealthCheckTimeout: 50 * time.Millisecond}}
+				logger = driver.Logger(cfg)
+				repo   = &mocks.HealthRepository{}
+				lc     = lifecycle.NewManager(0, 0, logger)
+			)
+			if tt.mockFunc != nil {
+				tt.mockFunc(repo)
+			}
+			if tt.shuttingDown {
+				_ = lc.Shutdown(nil)
+			}
+
+			got := NewHealthService(repo, cfg, logger, lc).Readiness(context.Background())
+			if got.IsUp() != tt.wantUp {
+				t.Errorf("HealthSrv.Readiness() up = %v, want %v", got.IsUp(), tt.wantUp)
+			}
+
+			components := map[string]string{}
+			for k, v := range got.Components {
+				components[k] = v.Status
+			}
+			if !reflect.DeepEqual(components, tt.wantComponents) {
+				t.Errorf("HealthSrv.Readiness() components = %v, want %v", components, tt.wantComponents)
+			}
+		})
+	}
+}
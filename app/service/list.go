@@ -0,0 +1,23 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// listPage is a page as cached, its data is kept rendered so that a cached page is
+// rendered as the fresh one was.
+type listPage struct {
+	Meta models.Meta     `json:"meta"`
+	Data json.RawMessage `json:"data"`
+}
+
+// listData is the page data as is, or reduced to the selected fields when the request has any.
+func listData(data interface{}, selects []string) (interface{}, error) {
+	if len(selects) == 0 {
+		return data, nil
+	}
+
+	return models.Project(data, selects)
+}
@@ -0,0 +1,61 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// AuditLogService is an autogenerated mock type for the AuditLogService type
+type AuditLogService struct {
+	mock.Mock
+}
+
+// GetList provides a mock function with given fields: ctx, req
+func (_m *AuditLogService) GetList(ctx context.Context, req dto.AuditLogListReq) (*models.Pagination, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 *models.Pagination
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AuditLogListReq) (*models.Pagination, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AuditLogListReq) *models.Pagination); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.AuditLogListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAuditLogService creates a new instance of AuditLogService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditLogService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditLogService {
+	mock := &AuditLogService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,295 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/adamnasrudin03/go-skeleton-mux/app/models"
+)
+
+// TeamMemberService is an autogenerated mock type for the TeamMemberService type
+type TeamMemberService struct {
+	mock.Mock
+}
+
+// Batch provides a mock function with given fields: ctx, req
+func (_m *TeamMemberService) Batch(ctx context.Context, req dto.TeamMemberBatchReq) (*dto.TeamMemberBatchRes, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 *dto.TeamMemberBatchRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberBatchReq) (*dto.TeamMemberBatchRes, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberBatchReq) *dto.TeamMemberBatchRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.TeamMemberBatchRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TeamMemberBatchReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *TeamMemberService) Create(ctx context.Context, req dto.TeamMemberCreateReq) (*models.TeamMember, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberCreateReq) (*models.TeamMember, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberCreateReq) *models.TeamMember); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TeamMemberCreateReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByID provides a mock function with given fields: ctx, id, version
+func (_m *TeamMemberService) DeleteByID(ctx context.Context, id uint64, version uint64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: ctx, req, fn
+func (_m *TeamMemberService) Export(ctx context.Context, req dto.TeamMemberListReq, fn func(models.TeamMember) error) error {
+	ret := _m.Called(ctx, req, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberListReq, func(models.TeamMember) error) error); ok {
+		r0 = rf(ctx, req, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *TeamMemberService) GetByID(ctx context.Context, id uint64) (*models.TeamMember, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.TeamMember, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.TeamMember); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetList provides a mock function with given fields: ctx, req
+func (_m *TeamMemberService) GetList(ctx context.Context, req dto.TeamMemberListReq) (*models.Pagination, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 *models.Pagination
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberListReq) (*models.Pagination, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberListReq) *models.Pagination); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TeamMemberListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: ctx, rows, dryRun
+func (_m *TeamMemberService) Import(ctx context.Context, rows dto.TeamMemberReader, dryRun bool) (*dto.TeamMemberImportReport, error) {
+	ret := _m.Called(ctx, rows, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 *dto.TeamMemberImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberReader, bool) (*dto.TeamMemberImportReport, error)); ok {
+		return rf(ctx, rows, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberReader, bool) *dto.TeamMemberImportReport); ok {
+		r0 = rf(ctx, rows, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.TeamMemberImportReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TeamMemberReader, bool) error); ok {
+		r1 = rf(ctx, rows, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Patch provides a mock function with given fields: ctx, req
+func (_m *TeamMemberService) Patch(ctx context.Context, req dto.TeamMemberPatchReq) (*models.TeamMember, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *models.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberPatchReq) (*models.TeamMember, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberPatchReq) *models.TeamMember); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TeamMemberPatchReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreByID provides a mock function with given fields: ctx, id
+func (_m *TeamMemberService) RestoreByID(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, req
+func (_m *TeamMemberService) Update(ctx context.Context, req dto.TeamMemberUpdateReq) (*models.TeamMember, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *models.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberUpdateReq) (*models.TeamMember, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TeamMemberUpdateReq) *models.TeamMember); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TeamMemberUpdateReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTeamMemberService creates a new instance of TeamMemberService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTeamMemberService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TeamMemberService {
+	mock := &TeamMemberService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,5 +2,8 @@ package service
 
 // Services all service object injected here
 type Services struct {
-	TeamMember TeamMemberService
+	AuditLog      AuditLogService
+	Authorization AuthorizationService
+	Health        HealthService
+	TeamMember    TeamMemberService
 }
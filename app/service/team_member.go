@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"time"
 
 	help "github.com/adamnasrudin03/go-helpers"
@@ -10,37 +12,84 @@ import (
 	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/repository"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/cache"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
+	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
 )
 
 type TeamMemberService interface {
-	checkDuplicate(ctx context.Context, req dto.TeamMemberDetailReq) error
 	Create(ctx context.Context, req dto.TeamMemberCreateReq) (*models.TeamMember, error)
 	GetByID(ctx context.Context, id uint64) (*models.TeamMember, error)
-	DeleteByID(ctx context.Context, id uint64) error
-	Update(ctx context.Context, req dto.TeamMemberUpdateReq) error
-	GetList(ctx context.Context, req dto.TeamMemberListReq) (*response_mapper.Pagination, error)
+	DeleteByID(ctx context.Context, id uint64, version uint64) error
+	RestoreByID(ctx context.Context, id uint64) error
+	Update(ctx context.Context, req dto.TeamMemberUpdateReq) (*models.TeamMember, error)
+	Patch(ctx context.Context, req dto.TeamMemberPatchReq) (*models.TeamMember, error)
+	GetList(ctx context.Context, req dto.TeamMemberListReq) (*models.Pagination, error)
+	Import(ctx context.Context, rows dto.TeamMemberReader, dryRun bool) (*dto.TeamMemberImportReport, error)
+	Export(ctx context.Context, req dto.TeamMemberListReq, fn func(member models.TeamMember) error) error
+	Batch(ctx context.Context, req dto.TeamMemberBatchReq) (*dto.TeamMemberBatchRes, error)
 }
 
 type TeamMemberSrv struct {
-	Repo   repository.TeamMemberRepository
-	Cfg    *configs.Configs
-	Logger *logrus.Logger
+	Repo      repository.TeamMemberRepository
+	Tx        repository.TxManager
+	Detail    *cache.Aside[models.TeamMember]
+	Lists     *cache.Aside[listPage]
+	ListsGen  *cache.Generation
+	Cfg       *configs.Configs
+	Logger    *logrus.Logger
+	Lifecycle *lifecycle.Manager
+	Validate  *validator.Validate
 }
 
 func NewTeamMemberService(
 	tmRepo repository.TeamMemberRepository,
+	txManager repository.TxManager,
+	redis driver.RedisClient,
 	cfg *configs.Configs,
 	logger *logrus.Logger,
+	lc *lifecycle.Manager,
+	validate *validator.Validate,
 ) TeamMemberService {
 	return &TeamMemberSrv{
-		Repo:   tmRepo,
-		Cfg:    cfg,
-		Logger: logger,
+		Repo: tmRepo,
+		Tx:   txManager,
+		// ids are never reused, a negative entry only hides a member created within
+		// its TTL of a lookup of the id it was given
+		Detail: cache.New[models.TeamMember](redis, cache.Options{
+			Name:        "team_member_detail",
+			TTL:         time.Minute,
+			Jitter:      0.1,
+			NegativeTTL: cfg.Redis.NegativeCacheTimeOut,
+		}),
+		Lists: cache.New[listPage](redis, cache.Options{
+			Name:   "team_member_list",
+			TTL:    time.Minute,
+			Jitter: 0.1,
+		}),
+		ListsGen:  cache.NewGeneration(redis, models.KeyCacheTeamMemberListGeneration),
+		Cfg:       cfg,
+		Logger:    logger,
+		Lifecycle: lc,
+		Validate:  validate,
 	}
 }
 
 func (s *TeamMemberSrv) Create(ctx context.Context, req dto.TeamMemberCreateReq) (*models.TeamMember, error) {
+	resp, err := s.create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateLists()
+	return resp, nil
+}
+
+// create creates the team member of req without invalidating the cached lists, left to the
+// caller so that an import or a batch creating many members invalidates them once.
+func (s *TeamMemberSrv) create(ctx context.Context, req dto.TeamMemberCreateReq) (*models.TeamMember, error) {
 	var (
 		opName = "TeamMemberService-Create"
 		err    error
@@ -50,166 +99,445 @@ func (s *TeamMemberSrv) Create(ctx context.Context, req dto.TeamMemberCreateReq)
 	req.Email = help.ToLower(req.Email)
 	req.UsernameGithub = help.ToLower(req.UsernameGithub)
 
-	err = s.checkDuplicate(ctx, dto.TeamMemberDetailReq{
-		Email:          req.Email,
-		UsernameGithub: req.UsernameGithub,
-	})
-	if err != nil {
-		return nil, err
-	}
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.lockUnique(ctx, req.Email, req.UsernameGithub)
+		if err != nil {
+			return err
+		}
+
+		err = s.checkDuplicate(ctx, dto.TeamMemberDetailReq{
+			Email:          req.Email,
+			UsernameGithub: req.UsernameGithub,
+		})
+		if err != nil {
+			return err
+		}
 
-	resp, err = s.Repo.Create(ctx, &models.TeamMember{
-		Name:           req.Name,
-		Email:          req.Email,
-		UsernameGithub: req.UsernameGithub,
+		resp, err = s.Repo.Create(ctx, &models.TeamMember{
+			Name:           req.Name,
+			Email:          req.Email,
+			UsernameGithub: req.UsernameGithub,
+		})
+		if err != nil {
+			s.Logger.Errorf("%s, failed create db: %v", opName, err)
+			return dbError(err, response_mapper.ErrCreatedDB())
+		}
+
+		return nil
 	})
 	if err != nil {
-		s.Logger.Errorf("%s, failed create db: %v", opName, err)
-		return nil, response_mapper.ErrCreatedDB()
+		return nil, s.txError(opName, err)
 	}
 
+	// the id may have been looked up and cached as missing before it was taken
+	s.invalidateDetail(resp.ID)
 	return resp, nil
 }
 
+// GetByID reads the team member id through the detail cache, writes use getDetail instead.
 func (s *TeamMemberSrv) GetByID(ctx context.Context, id uint64) (*models.TeamMember, error) {
 	var (
 		opName = "TeamMemberService-GetByID"
 		err    error
-		resp   models.TeamMember
 		key    = models.KeyCacheTeamMemberDetail(id)
 	)
 
-	ok := s.Repo.GetCache(ctx, key, &resp)
-	if ok && resp.ID > 0 {
-		return &resp, nil
-	}
+	detail, err := s.Detail.Get(ctx, key, func(ctx context.Context) (*models.TeamMember, error) {
+		detail, err := s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{
+			ID: id,
+		})
+		if err != nil {
+			s.Logger.Errorf("%s, failed get detail: %v", opName, err)
+			return nil, response_mapper.ErrDB()
+		}
 
-	detail, err := s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{
-		ID: id,
+		isExist := detail != nil && detail.ID > 0
+		if !isExist {
+			return nil, nil
+		}
+		return detail, nil
 	})
 	if err != nil {
-		s.Logger.Errorf("%s, failed get detail: %v", opName, err)
-		return nil, response_mapper.ErrDB()
+		return nil, err
 	}
-
-	isExist := detail != nil && detail.ID > 0
-	if !isExist {
+	if detail == nil {
 		return nil, response_mapper.ErrNotFound()
 	}
 
-	go s.Repo.CreateCache(context.Background(), key, detail, time.Minute)
-
 	return detail, nil
 }
 
-func (s *TeamMemberSrv) DeleteByID(ctx context.Context, id uint64) error {
+// DeleteByID soft deletes the team member, which must be at version unless it is 0.
+func (s *TeamMemberSrv) DeleteByID(ctx context.Context, id uint64, version uint64) error {
+	err := s.deleteByID(ctx, id, version)
+	if err != nil {
+		return err
+	}
+
+	s.invalidateLists()
+	return nil
+}
+
+// deleteByID is DeleteByID without invalidating the cached lists, see create.
+func (s *TeamMemberSrv) deleteByID(ctx context.Context, id uint64, version uint64) error {
 	var (
 		opName = "TeamMemberService-DeleteByID"
-		key    = models.KeyCacheTeamMemberDetail(id)
 		err    error
 	)
 
-	_, err = s.GetByID(ctx, id)
+	req := &models.TeamMember{ID: id, Version: version}
+	if caller, ok := models.CallerFromContext(ctx); ok {
+		req.DeletedBy = caller.ID
+	}
+
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := s.getDetail(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repo.Delete(ctx, req)
+		if errors.Is(err, repository.ErrTeamMemberNotFound) {
+			return response_mapper.ErrNotFound()
+		}
+		if err != nil {
+			s.Logger.Errorf("%s, failed delete db: %v", opName, err)
+			return dbError(err, response_mapper.ErrDB())
+		}
+
+		return nil
+	})
 	if err != nil {
-		s.Logger.Errorf("%s, failed get detail: %v", opName, err)
-		return err
+		return s.txError(opName, err)
 	}
 
-	err = s.Repo.Delete(ctx, &models.TeamMember{ID: id})
+	s.invalidateDetail(id)
+	return nil
+}
+
+func (s *TeamMemberSrv) RestoreByID(ctx context.Context, id uint64) error {
+	var (
+		opName = "TeamMemberService-RestoreByID"
+		err    error
+	)
+
+	detail, err := s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{
+		ID:             id,
+		IncludeDeleted: true,
+	})
 	if err != nil {
-		s.Logger.Errorf("%s, failed delete db: %v", opName, err)
+		s.Logger.Errorf("%s, failed get detail: %v", opName, err)
 		return response_mapper.ErrDB()
 	}
 
-	go s.Repo.DeleteCache(context.Background(), key)
+	isExist := detail != nil && detail.ID > 0
+	if !isExist {
+		return response_mapper.ErrNotFound()
+	}
+
+	if !detail.DeletedAt.Valid {
+		return response_mapper.NewError(response_mapper.ErrValidation, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: "Anggota tim tidak dalam status terhapus",
+				EN: "Team member is not deleted",
+			},
+		))
+	}
+
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.lockUnique(ctx, detail.Email, detail.UsernameGithub)
+		if err != nil {
+			return err
+		}
+
+		// an active member may have taken the email or username since the delete
+		err = s.checkDuplicate(ctx, dto.TeamMemberDetailReq{
+			Email:          detail.Email,
+			UsernameGithub: detail.UsernameGithub,
+			NotID:          id,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.Repo.Restore(ctx, &models.TeamMember{ID: id})
+		if err != nil {
+			s.Logger.Errorf("%s, failed restore db: %v", opName, err)
+			return dbError(err, response_mapper.ErrUpdatedDB())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return s.txError(opName, err)
+	}
+
+	s.invalidateDetail(id)
+	s.invalidateLists()
 
 	return nil
 }
 
-func (s *TeamMemberSrv) Update(ctx context.Context, req dto.TeamMemberUpdateReq) error {
+func (s *TeamMemberSrv) Update(ctx context.Context, req dto.TeamMemberUpdateReq) (*models.TeamMember, error) {
+	resp, err := s.update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateLists()
+	return resp, nil
+}
+
+// update is Update without invalidating the cached lists, see create.
+func (s *TeamMemberSrv) update(ctx context.Context, req dto.TeamMemberUpdateReq) (*models.TeamMember, error) {
 	var (
 		opName = "TeamMemberService-Update"
-		key    = models.KeyCacheTeamMemberDetail(req.ID)
 		err    error
+		resp   *models.TeamMember
 	)
 
-	_, err = s.GetByID(ctx, req.ID)
-	if err != nil {
-		s.Logger.Errorf("%s, failed get detail: %v", opName, err)
-		return err
-	}
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.lockUnique(ctx, req.Email, req.UsernameGithub)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.getDetail(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+
+		err = s.checkDuplicate(ctx, dto.TeamMemberDetailReq{
+			Email:          req.Email,
+			UsernameGithub: req.UsernameGithub,
+			NotID:          req.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.Repo.Update(ctx, &models.TeamMember{
+			ID:             req.ID,
+			Name:           req.Name,
+			Email:          req.Email,
+			UsernameGithub: req.UsernameGithub,
+			Version:        req.Version,
+		})
+		if err != nil {
+			s.Logger.Errorf("%s, failed update db: %v", opName, err)
+			return dbError(err, response_mapper.ErrUpdatedDB())
+		}
 
-	err = s.checkDuplicate(ctx, dto.TeamMemberDetailReq{
-		Email:          req.Email,
-		UsernameGithub: req.UsernameGithub,
-		NotID:          req.ID,
+		resp, err = s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{ID: req.ID})
+		if err != nil || resp == nil {
+			s.Logger.Errorf("%s, failed get detail: %v", opName, err)
+			return response_mapper.ErrDB()
+		}
+
+		return nil
 	})
 	if err != nil {
-		return err
+		return nil, s.txError(opName, err)
 	}
 
-	err = s.Repo.Update(ctx, &models.TeamMember{
-		ID:             req.ID,
-		Name:           req.Name,
-		Email:          req.Email,
-		UsernameGithub: req.UsernameGithub,
+	s.invalidateDetail(req.ID)
+	return resp, nil
+}
+
+// Patch applies req.Patch to the team member and writes only the columns it changed,
+// checking only those columns and the duplicates of a changed email or username_github.
+func (s *TeamMemberSrv) Patch(ctx context.Context, req dto.TeamMemberPatchReq) (*models.TeamMember, error) {
+	var (
+		opName    = "TeamMemberService-Patch"
+		err       error
+		resp      *models.TeamMember
+		isUpdated bool
+	)
+
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		detail, err := s.getDetail(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if req.Version > 0 && detail.Version != req.Version {
+			return models.NewVersionMismatch(req.ID, detail.Version, req.Version)
+		}
+
+		update, columns, err := req.Apply(*detail)
+		if err != nil {
+			return patchError(err)
+		}
+		if len(columns) == 0 {
+			resp = detail
+			return nil
+		}
+
+		var (
+			fields    []string
+			duplicate = dto.TeamMemberDetailReq{NotID: req.ID}
+		)
+		for _, column := range columns {
+			fields = append(fields, dto.TeamMemberPatchFields[column])
+			switch column {
+			case "email":
+				duplicate.Email = update.Email
+			case "username_github":
+				duplicate.UsernameGithub = update.UsernameGithub
+			}
+		}
+		err = s.validate(update, fields...)
+		if err != nil {
+			return err
+		}
+
+		if duplicate.Email != "" || duplicate.UsernameGithub != "" {
+			err = s.lockUnique(ctx, update.Email, update.UsernameGithub)
+			if err != nil {
+				return err
+			}
+
+			err = s.checkDuplicate(ctx, duplicate)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = s.Repo.UpdateFields(ctx, &models.TeamMember{
+			ID:             req.ID,
+			Name:           update.Name,
+			Email:          update.Email,
+			UsernameGithub: update.UsernameGithub,
+			Version:        update.Version,
+		}, columns...)
+		if err != nil {
+			s.Logger.Errorf("%s, failed update db: %v", opName, err)
+			return dbError(err, response_mapper.ErrUpdatedDB())
+		}
+		isUpdated = true
+
+		resp, err = s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{ID: req.ID})
+		if err != nil || resp == nil {
+			s.Logger.Errorf("%s, failed get detail: %v", opName, err)
+			return response_mapper.ErrDB()
+		}
+
+		return nil
 	})
 	if err != nil {
-		s.Logger.Errorf("%s, failed update db: %v", opName, err)
-		return response_mapper.ErrUpdatedDB()
+		return nil, s.txError(opName, err)
 	}
 
-	go s.Repo.DeleteCache(context.Background(), key)
-	return nil
+	if isUpdated {
+		s.invalidateDetail(req.ID)
+		s.invalidateLists()
+	}
+	return resp, nil
 }
 
-func (s *TeamMemberSrv) GetList(ctx context.Context, req dto.TeamMemberListReq) (*response_mapper.Pagination, error) {
+// GetList reads a page of team members through the cache, unless req.NoCache is set. The
+// pages are cached under the generation of the lists, which every write bumps.
+func (s *TeamMemberSrv) GetList(ctx context.Context, req dto.TeamMemberListReq) (*models.Pagination, error) {
 	var (
 		opName = "TeamMemberService-GetList"
 		err    error
-		resp   *response_mapper.Pagination
 	)
 	err = req.Validate()
 	if err != nil {
 		return nil, err
 	}
 
+	generation, err := s.ListsGen.Current()
+	if err != nil {
+		// without the generation a page could be cached after the write that outdates it
+		s.Logger.Errorf("%s, failed get list generation: %v", opName, err)
+		return s.getList(ctx, req)
+	}
+
+	var (
+		key   = models.KeyCacheTeamMemberList(generation, req.Hash())
+		fresh *models.Pagination
+		page  *listPage
+		load  = func(ctx context.Context) (*listPage, error) {
+			res, err := s.getList(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			data, err := json.Marshal(res.Data)
+			if err != nil {
+				s.Logger.Errorf("%s, failed marshal page: %v", opName, err)
+				return nil, response_mapper.NewError(response_mapper.ErrUnknown, err)
+			}
+			fresh = res
+			return &listPage{Meta: res.Meta, Data: data}, nil
+		}
+	)
+	if req.NoCache {
+		page, err = s.Lists.Refresh(ctx, key, load)
+	} else {
+		page, err = s.Lists.Get(ctx, key, load)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the page loaded by this call keeps its typed data
+	if fresh != nil {
+		return fresh, nil
+	}
+	return &models.Pagination{
+		Data: page.Data,
+		Meta: page.Meta,
+	}, nil
+}
+
+// getList reads a page of team members from the database, by cursor when req has one.
+func (s *TeamMemberSrv) getList(ctx context.Context, req dto.TeamMemberListReq) (*models.Pagination, error) {
+	var (
+		opName = "TeamMemberService-GetList"
+		err    error
+	)
+	if req.Cursor != "" {
+		return s.getListByCursor(ctx, req)
+	}
+
 	data, err := s.Repo.GetList(ctx, req)
 	if err != nil {
 		s.Logger.Errorf("%s, failed get list: %v", opName, err)
 		return nil, response_mapper.ErrDB()
 	}
 
-	totalRecords := len(data)
-	resp = &response_mapper.Pagination{
-		Data: data,
-		Meta: response_mapper.Meta{
-			Page:         req.Page,
-			Limit:        req.Limit,
-			TotalRecords: totalRecords,
-		},
+	total, isApproximate, err := s.countList(ctx, req, len(data))
+	if err != nil {
+		s.Logger.Errorf("%s, failed count: %v", opName, err)
+		return nil, response_mapper.ErrDB()
 	}
 
-	// total records in less than limit
-	if totalRecords > 0 && totalRecords != req.Limit {
-		return resp, nil
+	limit := req.Limit
+	if req.IsNoLimit {
+		limit = 0
 	}
-
-	// get total data
-	if totalRecords > 0 {
-		req.CustomColumns = "id"
-		req.IsNotDefaultQuery = true
-		req.Offset = (req.Page - 1) * req.Limit
-		req.Limit = models.DefaultLimitIsTotalDataTrue * req.Limit
-
-		total, err := s.Repo.GetList(ctx, req)
-		if err != nil {
-			s.Logger.Errorf("%s, failed get total data: %v", opName, err)
-			return nil, response_mapper.ErrDB()
+	meta := models.NewMeta(req.Page, limit, total)
+	meta.IsApproximate = isApproximate
+	// unsorted searches are ranked by relevance, which cursors can not page
+	isRanked := req.Search != "" && len(req.Sorts) == 0
+	if !req.IsNoLimit && !isRanked && len(req.Sorts) <= 1 {
+		// the repository breaks ties and orders unsorted lists by id
+		sort := models.Sort{Column: "id"}
+		if len(req.Sorts) == 1 {
+			sort = req.Sorts[0]
 		}
-		totalRecords = len(total)
-		resp.Meta.TotalRecords = totalRecords
+		s.setCursors(&meta, data, sort)
 	}
 
-	return resp, nil
+	resp, err := listData(data, req.Selects)
+	if err != nil {
+		s.Logger.Errorf("%s, failed select fields: %v", opName, err)
+		return nil, response_mapper.NewError(response_mapper.ErrUnknown, err)
+	}
+
+	return &models.Pagination{
+		Data: resp,
+		Meta: meta,
+	}, nil
 }
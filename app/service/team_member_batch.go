@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+)
+
+// Batch runs the operations of req in order with the same rules and duplicate checks as
+// Create, Update and DeleteByID. An atomic batch runs them in one transaction and stops
+// at the first failure, rolling back the operations before it, a best effort batch runs
+// each one on its own. Failed operations are reported in their result, only an invalid
+// batch is returned as an error.
+func (s *TeamMemberSrv) Batch(ctx context.Context, req dto.TeamMemberBatchReq) (*dto.TeamMemberBatchRes, error) {
+	var (
+		opName = "TeamMemberService-Batch"
+		err    error
+	)
+	err = req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.TeamMemberBatchRes{Mode: req.Mode, Results: make([]dto.TeamMemberBatchResult, len(req.Operations))}
+	for i, op := range req.Operations {
+		res.Results[i] = dto.TeamMemberBatchResult{Index: i, Op: op.Op, ID: op.ID}
+	}
+
+	if req.Mode == dto.BatchModeBestEffort {
+		for i, op := range req.Operations {
+			res.Results[i].ID, res.Results[i].Err = s.runBatchOperation(ctx, op)
+		}
+	} else {
+		var (
+			failed = -1
+			opErr  error
+		)
+		err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			for i, op := range req.Operations {
+				res.Results[i].ID, opErr = s.runBatchOperation(ctx, op)
+				if opErr != nil {
+					failed = i
+					return opErr
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if failed < 0 {
+				// every operation ran, the commit failed
+				opErr = s.txError(opName, err)
+			}
+			for i := range res.Results {
+				result := &res.Results[i]
+				switch {
+				case failed < 0 || i == failed:
+					result.Err = opErr
+				case i < failed:
+					result.Err = dto.ErrBatchRolledBack
+				default:
+					result.Err = dto.ErrBatchNotRun
+				}
+				if result.Op == dto.BatchOpCreate {
+					result.ID = 0
+				}
+			}
+		}
+	}
+
+	var ids []uint64
+	for _, result := range res.Results {
+		if result.Err != nil {
+			res.Failed++
+			continue
+		}
+
+		res.Succeeded++
+		ids = append(ids, result.ID)
+	}
+	res.Committed = res.Succeeded > 0
+
+	if res.Committed {
+		// the operations of an atomic batch invalidate the cache before the commit, a read
+		// in between may have cached the old rows, or a created id as missing, again
+		if req.Mode == dto.BatchModeAtomic {
+			for _, id := range ids {
+				s.invalidateDetail(id)
+			}
+		}
+		// once for the whole batch, the operations leave it to the caller
+		s.invalidateLists()
+	}
+
+	return res, nil
+}
+
+// runBatchOperation checks and runs op, returning the id of the team member it wrote.
+func (s *TeamMemberSrv) runBatchOperation(ctx context.Context, op dto.TeamMemberBatchOperation) (uint64, error) {
+	switch op.Op {
+	case dto.BatchOpCreate:
+		err := s.validate(op.Data)
+		if err != nil {
+			return 0, err
+		}
+
+		member, err := s.create(ctx, op.Data)
+		if err != nil {
+			return 0, err
+		}
+		return member.ID, nil
+	case dto.BatchOpUpdate:
+		req := dto.TeamMemberUpdateReq{
+			ID:             op.ID,
+			Name:           op.Data.Name,
+			UsernameGithub: op.Data.UsernameGithub,
+			Email:          op.Data.Email,
+			Version:        op.Version,
+		}
+		err := s.validate(req)
+		if err != nil {
+			return op.ID, err
+		}
+
+		_, err = s.update(ctx, req)
+		return op.ID, err
+	default:
+		return op.ID, s.deleteByID(ctx, op.ID, op.Version)
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Batch() {
+	var (
+		adam   = dto.TeamMemberCreateReq{Name: "Adam", UsernameGithub: "Adam", Email: "adam@example.com"}
+		member = dto.TeamMemberCreateReq{Name: "adam nasrudin", UsernameGithub: "adamnasrudin03", Email: "adam@example.com"}
+		// mockCreate expects adam to be created as id 7
+		mockCreate = func() {
+			srv.mockNoDuplicate("adam@example.com", "adam")
+			srv.repo.On("Create", mock.Anything, &models.TeamMember{
+				Name:           "Adam",
+				Email:          "adam@example.com",
+				UsernameGithub: "adam",
+			}).Return(&models.TeamMember{ID: 7}, nil).Once()
+			srv.cache.On("Del", models.KeyCacheTeamMemberDetail(7)).Return(nil).Maybe()
+		}
+		// mockDetail expects the detail of id 2 to be read from the database, never cached
+		// inside the transaction
+		mockDetail = func() {
+			srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: 2, ForUpdate: true}).Return(&srv.teamMembers[1], nil).Once()
+			srv.cache.On("Del", models.KeyCacheTeamMemberDetail(2)).Return(nil).Maybe()
+		}
+	)
+	type result struct {
+		id  uint64
+		err error
+	}
+	tests := []struct {
+		name          string
+		req           dto.TeamMemberBatchReq
+		mockFunc      func()
+		wantErr       bool
+		wantCommitted bool
+		want          []result
+	}{
+		{
+			name:    "invalid batch",
+			req:     dto.TeamMemberBatchReq{Mode: dto.BatchModeAtomic},
+			wantErr: true,
+		},
+		{
+			name: "best effort keeps the operations that succeed",
+			req: dto.TeamMemberBatchReq{Mode: dto.BatchModeBestEffort, Operations: []dto.TeamMemberBatchOperation{
+				{Op: dto.BatchOpCreate, Data: adam},
+				{Op: dto.BatchOpCreate, Data: dto.TeamMemberCreateReq{Name: "Invalid", UsernameGithub: "invalid", Email: "not-an-email"}},
+			}},
+			mockFunc:      mockCreate,
+			wantCommitted: true,
+			want:          []result{{id: 7}, {err: errors.New("Email must be an email address")}},
+		},
+		{
+			name: "atomic rolls back at the first failure",
+			req: dto.TeamMemberBatchReq{Operations: []dto.TeamMemberBatchOperation{
+				{Op: dto.BatchOpCreate, Data: adam},
+				{Op: dto.BatchOpUpdate, ID: 2, Data: member},
+				{Op: dto.BatchOpDelete, ID: 3},
+			}},
+			mockFunc: func() {
+				mockCreate()
+				mockDetail()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn: "id",
+					Email:        "adam@example.com",
+					NotID:        2,
+				}).Return(&models.TeamMember{ID: 7}, nil).Once()
+			},
+			want: []result{{err: dto.ErrBatchRolledBack}, {id: 2, err: errors.New("email already exists")}, {id: 3, err: dto.ErrBatchNotRun}},
+		},
+		{
+			name: "atomic commits every operation",
+			req: dto.TeamMemberBatchReq{Operations: []dto.TeamMemberBatchOperation{
+				{Op: dto.BatchOpDelete, ID: 2},
+			}},
+			mockFunc: func() {
+				mockDetail()
+				srv.repo.On("Delete", mock.Anything, &models.TeamMember{ID: 2}).Return(nil).Once()
+			},
+			wantCommitted: true,
+			want:          []result{{id: 2}},
+		},
+	}
+	for _, tt := range tests {
+		srv.T().Run(tt.name, func(t *testing.T) {
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			bumps := countCalls(&srv.cache.Mock, "Incr")
+			got, err := srv.service.Batch(srv.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberSrv.Batch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			// a row read inside the transaction must not reach the cache before the commit
+			srv.cache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+
+			if got.Committed != tt.wantCommitted {
+				t.Errorf("TeamMemberSrv.Batch() committed = %v, want %v", got.Committed, tt.wantCommitted)
+			}
+			// the lists are invalidated once per committed batch, not once per operation
+			wantBumps := 0
+			if tt.wantCommitted {
+				wantBumps = 1
+			}
+			if bumps = countCalls(&srv.cache.Mock, "Incr") - bumps; bumps != wantBumps {
+				t.Errorf("TeamMemberSrv.Batch() list generation bumped %d times, want %d", bumps, wantBumps)
+			}
+			if len(got.Results) != len(tt.want) {
+				t.Fatalf("TeamMemberSrv.Batch() results = %+v, want %+v", got.Results, tt.want)
+			}
+			for i, want := range tt.want {
+				result := got.Results[i]
+				if result.Index != i || result.ID != want.id || (result.Err == nil) != (want.err == nil) ||
+					(want.err != nil && errorMessage(result.Err) != want.err.Error()) {
+					t.Errorf("TeamMemberSrv.Batch() result %d = %+v, want %+v", i, result, want)
+				}
+			}
+		})
+	}
+}
+
+// countCalls returns the number of calls to method m has recorded.
+func countCalls(m *mock.Mock, method string) int {
+	count := 0
+	for _, call := range m.Calls {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
@@ -2,44 +2,253 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/utils"
 )
 
+// checkDuplicate fails when the email or username_github of req is taken, an empty one is not checked.
 func (s *TeamMemberSrv) checkDuplicate(ctx context.Context, req dto.TeamMemberDetailReq) error {
 	var (
 		opName = "TeamMemberService-checkDuplicate"
 		err    error
+		detail *models.TeamMember
 	)
-	detail, err := s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{
-		CustomColumn: "id",
-		Email:        req.Email,
-		NotID:        req.NotID,
-	})
+	if req.Email != "" {
+		detail, err = s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{
+			CustomColumn: "id",
+			Email:        req.Email,
+			NotID:        req.NotID,
+		})
+		if err != nil {
+			s.Logger.Errorf("%s, failed check duplicate email: %v", opName, err)
+			return response_mapper.ErrDB()
+		}
+
+		if detail != nil && detail.ID > 0 {
+			return errDuplicate("email")
+		}
+	}
+
+	if req.UsernameGithub != "" {
+		detail, err = s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{
+			CustomColumn:   "id",
+			UsernameGithub: req.UsernameGithub,
+			NotID:          req.NotID,
+		})
+		if err != nil {
+			s.Logger.Errorf("%s, failed check duplicate username_github: %v", opName, err)
+			return response_mapper.ErrDB()
+		}
+
+		if detail != nil && detail.ID > 0 {
+			return errDuplicate("username_github")
+		}
+	}
+
+	return nil
+}
+
+// lockUnique serializes the transactions writing the same email or username_github,
+// so checkDuplicate stays true until the write is committed.
+func (s *TeamMemberSrv) lockUnique(ctx context.Context, email, usernameGithub string) error {
+	var (
+		opName = "TeamMemberService-lockUnique"
+		err    error
+	)
+	err = s.Tx.Lock(ctx, models.KeyLockTeamMemberEmail(email), models.KeyLockTeamMemberUsernameGithub(usernameGithub))
 	if err != nil {
-		s.Logger.Errorf("%s, failed check duplicate email: %v", opName, err)
+		s.Logger.Errorf("%s, failed lock: %v", opName, err)
 		return response_mapper.ErrDB()
 	}
 
-	if detail != nil && detail.ID > 0 {
-		return response_mapper.ErrIsDuplicate("email", "email")
+	return nil
+}
+
+// txError returns the error of a transaction as is when it comes from the service,
+// otherwise the transaction itself failed, for example on commit.
+func (s *TeamMemberSrv) txError(opName string, err error) error {
+	var respErr *response_mapper.ResponseError
+	if errors.As(err, &respErr) {
+		return err
 	}
 
-	detail, err = s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{
-		CustomColumn:   "id",
-		UsernameGithub: req.UsernameGithub,
-		NotID:          req.NotID,
-	})
+	s.Logger.Errorf("%s, failed transaction: %v", opName, err)
+	return dbError(err, response_mapper.ErrDB())
+}
+
+// getDetail reads and locks the team member id in the transaction of ctx, writes read it there
+// rather than through s.Detail since a row read inside a transaction must not be cached before
+// the commit.
+func (s *TeamMemberSrv) getDetail(ctx context.Context, id uint64) (*models.TeamMember, error) {
+	detail, err := s.Repo.GetDetail(ctx, dto.TeamMemberDetailReq{ID: id, ForUpdate: true})
 	if err != nil {
-		s.Logger.Errorf("%s, failed check duplicate username_github: %v", opName, err)
-		return response_mapper.ErrDB()
+		s.Logger.Errorf("TeamMemberService-getDetail, failed get detail: %v", err)
+		return nil, response_mapper.ErrDB()
 	}
+	if detail == nil || detail.ID == 0 {
+		return nil, response_mapper.ErrNotFound()
+	}
+
+	return detail, nil
+}
 
-	if detail != nil && detail.ID > 0 {
-		return response_mapper.ErrIsDuplicate("username_github", "username_github")
+// invalidateDetail drops the cached row of id once its write is committed, before the
+// response so a read following it gets the new row and ETag.
+func (s *TeamMemberSrv) invalidateDetail(id uint64) {
+	err := s.Detail.Delete(models.KeyCacheTeamMemberDetail(id))
+	if err != nil {
+		s.Logger.Errorf("TeamMemberService-invalidateDetail, failed delete cache: %v", err)
 	}
+}
 
-	return nil
+// invalidateLists moves the cached lists to a new generation once a write is committed, before
+// the response so a list following it does not get a page cached before the write.
+func (s *TeamMemberSrv) invalidateLists() {
+	err := s.ListsGen.Bump()
+	if err != nil {
+		s.Logger.Errorf("TeamMemberService-invalidateLists, failed bump list generation: %v", err)
+	}
+}
+
+// patchError returns the error of a patch that could not be applied, a failed test
+// operation is a conflict with the current team member.
+func patchError(err error) error {
+	code := response_mapper.ErrFromUseCase
+	if errors.Is(err, dto.ErrPatchTestFailed) {
+		code = response_mapper.ErrConflict
+	}
+
+	return response_mapper.NewError(code, response_mapper.NewResponseMultiLang(
+		response_mapper.MultiLanguages{
+			ID: err.Error(),
+			EN: err.Error(),
+		},
+	))
+}
+
+// errDuplicate is a conflict on field, response_mapper.ErrIsDuplicate is a validation error.
+func errDuplicate(field string) error {
+	return response_mapper.NewError(response_mapper.ErrConflict, response_mapper.NewResponseMultiLang(
+		response_mapper.MultiLanguages{
+			ID: fmt.Sprintf("%s sudah ada", field),
+			EN: fmt.Sprintf("%s already exists", field),
+		},
+	))
+}
+
+// countList returns the total of the list filtered by req, found is the number of rows
+// on the requested page. A page that is not full ends the list so its total needs no
+// query, large unfiltered tables use the planner estimate when it is configured.
+func (s *TeamMemberSrv) countList(ctx context.Context, req dto.TeamMemberListReq, found int) (int64, bool, error) {
+	if req.IsNoLimit {
+		return int64(found), false, nil
+	}
+	if found > 0 && found < req.Limit {
+		return int64((req.Page-1)*req.Limit + found), false, nil
+	}
+
+	isFiltered := req.Search != "" || req.IncludeDeleted || !req.Filter.IsEmpty()
+	if s.Cfg.DB.CountEstimateThreshold > 0 && !isFiltered {
+		estimate, err := s.Repo.EstimateCount(ctx)
+		if err != nil {
+			return 0, false, err
+		}
+		if estimate >= s.Cfg.DB.CountEstimateThreshold {
+			return estimate, true, nil
+		}
+	}
+
+	total, err := s.Repo.Count(ctx, req)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return total, false, nil
+}
+
+// getListByCursor reads the page after or before the signed req.Cursor, the page carries
+// no totals since counting is what paging by cursor avoids.
+func (s *TeamMemberSrv) getListByCursor(ctx context.Context, req dto.TeamMemberListReq) (*models.Pagination, error) {
+	var (
+		opName = "TeamMemberService-getListByCursor"
+		cursor models.Cursor
+	)
+	err := utils.DecodeCursor(s.Cfg.App.CursorSecret, req.Cursor, &cursor)
+	if err != nil || !models.TeamMemberCursorColumns[cursor.SortBy] {
+		return nil, response_mapper.ErrInvalidFormat("cursor", "cursor")
+	}
+	sort := cursor.Sort()
+	if len(req.Sorts) > 1 || (len(req.Sorts) == 1 && req.Sorts[0] != sort) {
+		return nil, response_mapper.NewError(response_mapper.ErrValidation, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: "cursor tidak sesuai dengan sort",
+				EN: "cursor does not match sort",
+			},
+		))
+	}
+
+	req.Sorts, req.Keyset = []models.Sort{sort}, &cursor
+	data, err := s.Repo.GetList(ctx, req)
+	if err != nil {
+		s.Logger.Errorf("%s, failed get list: %v", opName, err)
+		return nil, response_mapper.ErrDB()
+	}
+
+	hasMore := len(data) > req.Limit
+	if hasMore {
+		data = data[:req.Limit]
+	}
+	if cursor.Backward {
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	meta := models.Meta{
+		Limit:   req.Limit,
+		HasNext: hasMore || cursor.Backward,
+		HasPrev: hasMore || !cursor.Backward,
+	}
+	s.setCursors(&meta, data, sort)
+
+	resp, err := listData(data, req.Selects)
+	if err != nil {
+		s.Logger.Errorf("%s, failed select fields: %v", opName, err)
+		return nil, response_mapper.NewError(response_mapper.ErrUnknown, err)
+	}
+
+	return &models.Pagination{
+		Data: resp,
+		Meta: meta,
+	}, nil
+}
+
+// setCursors points meta.NextCursor past the last row and meta.PrevCursor before the first one,
+// no cursor is issued without a secret to sign it, which only happens outside of serve.
+func (s *TeamMemberSrv) setCursors(meta *models.Meta, data []models.TeamMember, sort models.Sort) {
+	if len(data) == 0 || !models.TeamMemberCursorColumns[sort.Column] || s.Cfg.App.CursorSecret == "" {
+		return
+	}
+
+	if meta.HasNext {
+		meta.NextCursor = s.encodeCursor(data[len(data)-1].Cursor(sort, false))
+	}
+	if meta.HasPrev {
+		meta.PrevCursor = s.encodeCursor(data[0].Cursor(sort, true))
+	}
+}
+
+func (s *TeamMemberSrv) encodeCursor(cursor models.Cursor) string {
+	token, err := utils.EncodeCursor(s.Cfg.App.CursorSecret, cursor)
+	if err != nil {
+		s.Logger.Errorf("TeamMemberService-encodeCursor, failed encode: %v", err)
+		return ""
+	}
 
+	return token
 }
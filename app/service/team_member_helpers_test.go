@@ -104,7 +104,7 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_checkDuplicate() {
 				tt.mockFunc(tt.req)
 			}
 
-			if err := srv.service.checkDuplicate(srv.ctx, tt.req); (err != nil) != tt.wantErr {
+			if err := srv.service.(*TeamMemberSrv).checkDuplicate(srv.ctx, tt.req); (err != nil) != tt.wantErr {
 				t.Errorf("TeamMemberSrv.checkDuplicate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
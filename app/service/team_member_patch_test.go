@@ -0,0 +1,103 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Patch() {
+	var (
+		key        = models.KeyCacheTeamMemberDetail(srv.teamMember.ID)
+		mergePatch = func(body string) dto.TeamMemberPatchReq {
+			patch, err := dto.NewTeamMemberPatch(dto.ContentTypeMergePatch, []byte(body))
+			if err != nil {
+				srv.T().Fatalf("NewTeamMemberPatch() error = %v", err)
+			}
+			return dto.TeamMemberPatchReq{ID: srv.teamMember.ID, Patch: patch}
+		}
+		mockDetail = func() {
+			srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: srv.teamMember.ID, ForUpdate: true}).Return(&srv.teamMember, nil).Once()
+		}
+		renamed = models.TeamMember{ID: srv.teamMember.ID, Name: "adam nasrudin", UsernameGithub: srv.teamMember.UsernameGithub, Email: srv.teamMember.Email}
+		stale   = mergePatch(`{"name":"adam nasrudin"}`)
+	)
+	stale.Version = srv.teamMember.Version + 1
+	tests := []struct {
+		name     string
+		req      dto.TeamMemberPatchReq
+		mockFunc func()
+		want     *models.TeamMember
+		wantErr  bool
+	}{
+		{
+			name: "not found",
+			req:  mergePatch(`{"name":"adam nasrudin"}`),
+			mockFunc: func() {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: srv.teamMember.ID, ForUpdate: true}).Return(nil, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:     "stale version",
+			req:      stale,
+			mockFunc: mockDetail,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid patched field",
+			req:      mergePatch(`{"name":null}`),
+			mockFunc: mockDetail,
+			wantErr:  true,
+		},
+		{
+			name:     "nothing changed",
+			req:      mergePatch(`{"email":"ADAM@example.com"}`),
+			mockFunc: mockDetail,
+			want:     &srv.teamMember,
+		},
+		{
+			name: "email duplicate",
+			req:  mergePatch(`{"email":"budi@example.com"}`),
+			mockFunc: func() {
+				mockDetail()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn: "id",
+					Email:        "budi@example.com",
+					NotID:        srv.teamMember.ID,
+				}).Return(&models.TeamMember{ID: 2}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "updates only the changed column",
+			req:  mergePatch(`{"name":"adam nasrudin","username_github":"adamnasrudin.vercel.app"}`),
+			mockFunc: func() {
+				mockDetail()
+				srv.repo.On("UpdateFields", mock.Anything, &renamed, "name").Return(nil).Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: srv.teamMember.ID}).Return(&renamed, nil).Once()
+				srv.cache.On("Del", key).Return(nil).Maybe()
+			},
+			want: &renamed,
+		},
+	}
+	for _, tt := range tests {
+		srv.T().Run(tt.name, func(t *testing.T) {
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			got, err := srv.service.Patch(srv.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberSrv.Patch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TeamMemberSrv.Patch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/url"
 	"reflect"
 	"testing"
 	"time"
@@ -10,17 +12,25 @@ import (
 	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository"
 	"github.com/adamnasrudin03/go-skeleton-mux/app/repository/mocks"
 	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	driverMocks "github.com/adamnasrudin03/go-skeleton-mux/pkg/driver/mocks"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/utils"
 
 	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 )
 
 type TeamMemberServiceTestSuite struct {
 	suite.Suite
 	repo        *mocks.TeamMemberRepository
+	tx          *mocks.TxManager
+	cache       *driverMocks.RedisClient
 	ctx         context.Context
 	service     TeamMemberService
 	teamMember  models.TeamMember
@@ -29,7 +39,7 @@ type TeamMemberServiceTestSuite struct {
 
 func (srv *TeamMemberServiceTestSuite) SetupTest() {
 	var (
-		cfg    = &configs.Configs{}
+		cfg    = &configs.Configs{App: configs.AppConfig{CursorSecret: testCursorSecret}}
 		logger = driver.Logger(cfg)
 	)
 	srv.teamMember = models.TeamMember{
@@ -49,8 +59,29 @@ func (srv *TeamMemberServiceTestSuite) SetupTest() {
 	}
 
 	srv.repo = &mocks.TeamMemberRepository{}
+	srv.tx = &mocks.TxManager{}
+	srv.cache = &driverMocks.RedisClient{}
+	// lists are read from the database as while Redis is down, see TestTeamMemberSrv_GetListCache
+	srv.cache.On("Get", models.KeyCacheTeamMemberListGeneration).Return("", errors.New("redis is down")).Maybe()
+	srv.cache.On("Incr", models.KeyCacheTeamMemberListGeneration).Return(int64(1), nil).Maybe()
+	srv.tx.On("WithinTransaction", mock.Anything, mock.Anything).Return(passThroughTransaction)
+	srv.tx.On("Lock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	srv.ctx = context.Background()
-	srv.service = NewTeamMemberService(srv.repo, cfg, logger)
+	srv.service = NewTeamMemberService(srv.repo, srv.tx, srv.cache, cfg, logger, lifecycle.NewManager(0, 0, logger), validator.New())
+}
+
+// testCursorSecret signs the cursors of the service under test.
+const testCursorSecret = "secret"
+
+// testCursor is the cursor the service signs for member in an ascending list.
+func testCursor(member models.TeamMember, sortBy string, backward bool) string {
+	token, _ := utils.EncodeCursor(testCursorSecret, member.Cursor(models.Sort{Column: sortBy}, backward))
+	return token
+}
+
+// passThroughTransaction runs fn without a database, as TxManager.WithinTransaction does on commit.
+func passThroughTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
 }
 
 func TestTeamMemberService(t *testing.T) {
@@ -70,25 +101,28 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_GetByID() {
 			id:   srv.teamMember.ID,
 			mockFunc: func(input uint64) {
 				key := models.KeyCacheTeamMemberDetail(input)
-				res := srv.teamMember
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{
-					ID: 0,
-				}).Return(true).Run(func(args mock.Arguments) {
-					target := args.Get(2).(*models.TeamMember)
-					*target = res
-				}).Once()
+				res, _ := json.Marshal(map[string]interface{}{"value": srv.teamMember})
+				srv.cache.On("Get", key).Return(string(res), nil).Once()
 			},
 			want:    &srv.teamMember,
 			wantErr: false,
 		},
+		{
+			name: "not found with cache",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				key := models.KeyCacheTeamMemberDetail(input)
+				srv.cache.On("Get", key).Return(`{"missing":true}`, nil).Once()
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "failed ge db",
 			id:   srv.teamMember.ID,
 			mockFunc: func(input uint64) {
 				key := models.KeyCacheTeamMemberDetail(input)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{
-					ID: 0,
-				}).Return(false).Once()
+				srv.cache.On("Get", key).Return("", driver.ErrNil).Once()
 
 				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
 					ID: input,
@@ -102,9 +136,7 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_GetByID() {
 			id:   srv.teamMember.ID,
 			mockFunc: func(input uint64) {
 				key := models.KeyCacheTeamMemberDetail(input)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{
-					ID: 0,
-				}).Return(false).Once()
+				srv.cache.On("Get", key).Return("", driver.ErrNil).Once()
 
 				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
 					ID: input,
@@ -118,15 +150,13 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_GetByID() {
 			id:   srv.teamMember.ID,
 			mockFunc: func(input uint64) {
 				key := models.KeyCacheTeamMemberDetail(input)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{
-					ID: 0,
-				}).Return(false).Once()
+				srv.cache.On("Get", key).Return("", driver.ErrNil).Once()
 
 				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
 					ID: input,
 				}).Return(&srv.teamMember, nil).Once()
 
-				srv.repo.On("CreateCache", mock.Anything, key, &srv.teamMember, time.Minute).Return().Once()
+				srv.cache.On("Set", key, mock.Anything, mock.Anything).Return(nil).Once()
 
 			},
 			want:    &srv.teamMember,
@@ -207,6 +237,34 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Create() {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "unique violation on commit race",
+			req:  params,
+			mockFunc: func(input dto.TeamMemberCreateReq) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn: "id",
+					Email:        input.Email,
+				}).Return(nil, nil).Once()
+
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn:   "id",
+					UsernameGithub: input.UsernameGithub,
+				}).Return(nil, nil).Once()
+
+				record := &models.TeamMember{
+					Name:           input.Name,
+					Email:          input.Email,
+					UsernameGithub: input.UsernameGithub,
+				}
+				srv.repo.On("Create", mock.Anything, record).Return(nil, &models.DBError{
+					Kind:  models.DBErrUniqueViolation,
+					Field: "email",
+				}).Once()
+
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "success",
 			req:  params,
@@ -227,7 +285,8 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Create() {
 					UsernameGithub: input.UsernameGithub,
 				}
 				srv.repo.On("Create", mock.Anything, record).Return(&srv.teamMember, nil).Once()
-
+				// the id may be cached as missing
+				srv.cache.On("Del", models.KeyCacheTeamMemberDetail(srv.teamMember.ID)).Return(nil).Once()
 			},
 			want:    &srv.teamMember,
 			wantErr: false,
@@ -262,29 +321,36 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_DeleteByID() {
 			name: "not found",
 			id:   srv.teamMember.ID,
 			mockFunc: func(input uint64) {
-				key := models.KeyCacheTeamMemberDetail(input)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{
-					ID: 0,
-				}).Return(false).Once()
-
 				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
-					ID: input,
+					ID:        input,
+					ForUpdate: true,
 				}).Return(nil, nil).Once()
 			},
 			wantErr: true,
 		},
+		{
+			name: "failed get detail",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input, ForUpdate: true}).Return(nil, errors.New("invalid")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "deleted by another request",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input, ForUpdate: true}).Return(&srv.teamMember, nil).Once()
+				srv.repo.On("Delete", mock.Anything, &models.TeamMember{ID: input}).Return(repository.ErrTeamMemberNotFound).Once()
+			},
+			wantErr: true,
+		},
 		{
 			name: "failed delete",
 			id:   srv.teamMember.ID,
 			mockFunc: func(input uint64) {
-				key := models.KeyCacheTeamMemberDetail(input)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{
-					ID: 0,
-				}).Return(false).Once()
-
 				record := &models.TeamMember{ID: input}
-				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input}).Return(record, nil).Once()
-				srv.repo.On("CreateCache", mock.Anything, key, record, time.Minute).Return().Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input, ForUpdate: true}).Return(record, nil).Once()
 
 				srv.repo.On("Delete", mock.Anything, &models.TeamMember{
 					ID: input,
@@ -296,16 +362,10 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_DeleteByID() {
 			name: "success",
 			id:   srv.teamMember.ID,
 			mockFunc: func(input uint64) {
-				key := models.KeyCacheTeamMemberDetail(input)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{
-					ID: 0,
-				}).Return(false).Once()
-
-				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input}).Return(&srv.teamMember, nil).Once()
-				srv.repo.On("CreateCache", mock.Anything, key, &srv.teamMember, time.Minute).Return().Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input, ForUpdate: true}).Return(&srv.teamMember, nil).Once()
 
 				srv.repo.On("Delete", mock.Anything, &models.TeamMember{ID: input}).Return(nil).Once()
-				srv.repo.On("DeleteCache", mock.Anything, key).Return().Once()
+				srv.cache.On("Del", models.KeyCacheTeamMemberDetail(input)).Return(nil).Once()
 			},
 			wantErr: false,
 		},
@@ -316,14 +376,136 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_DeleteByID() {
 				tt.mockFunc(tt.id)
 			}
 
-			if err := srv.service.DeleteByID(srv.ctx, tt.id); (err != nil) != tt.wantErr {
+			if err := srv.service.DeleteByID(srv.ctx, tt.id, 0); (err != nil) != tt.wantErr {
 				t.Errorf("TeamMemberSrv.DeleteByID() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_RestoreByID() {
+	deleted := srv.teamMember
+	deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	tests := []struct {
+		name     string
+		id       uint64
+		mockFunc func(input uint64)
+		wantErr  bool
+	}{
+		{
+			name: "failed get detail",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					ID:             input,
+					IncludeDeleted: true,
+				}).Return(nil, errors.New("invalid")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "not found",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					ID:             input,
+					IncludeDeleted: true,
+				}).Return(nil, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "not deleted",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					ID:             input,
+					IncludeDeleted: true,
+				}).Return(&srv.teamMember, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "email taken by an active member",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					ID:             input,
+					IncludeDeleted: true,
+				}).Return(&deleted, nil).Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn: "id",
+					Email:        deleted.Email,
+					NotID:        input,
+				}).Return(&models.TeamMember{ID: 2}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed restore",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					ID:             input,
+					IncludeDeleted: true,
+				}).Return(&deleted, nil).Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn: "id",
+					Email:        deleted.Email,
+					NotID:        input,
+				}).Return(nil, nil).Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn:   "id",
+					UsernameGithub: deleted.UsernameGithub,
+					NotID:          input,
+				}).Return(nil, nil).Once()
+
+				srv.repo.On("Restore", mock.Anything, &models.TeamMember{ID: input}).Return(errors.New("invalid")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			id:   srv.teamMember.ID,
+			mockFunc: func(input uint64) {
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					ID:             input,
+					IncludeDeleted: true,
+				}).Return(&deleted, nil).Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn: "id",
+					Email:        deleted.Email,
+					NotID:        input,
+				}).Return(nil, nil).Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn:   "id",
+					UsernameGithub: deleted.UsernameGithub,
+					NotID:          input,
+				}).Return(nil, nil).Once()
+
+				srv.repo.On("Restore", mock.Anything, &models.TeamMember{ID: input}).Return(nil).Once()
+				srv.cache.On("Del", models.KeyCacheTeamMemberDetail(input)).Return(nil).Once()
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		srv.T().Run(tt.name, func(t *testing.T) {
+			if tt.mockFunc != nil {
+				tt.mockFunc(tt.id)
+			}
+
+			if err := srv.service.RestoreByID(srv.ctx, tt.id); (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberSrv.RestoreByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Update() {
+	updated := srv.teamMember
+	updated.Version = 2
 	params := dto.TeamMemberUpdateReq{
 		ID:             srv.teamMember.ID,
 		Name:           srv.teamMember.Name,
@@ -335,15 +517,14 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Update() {
 		name     string
 		req      dto.TeamMemberUpdateReq
 		mockFunc func(input dto.TeamMemberUpdateReq)
+		want     *models.TeamMember
 		wantErr  bool
 	}{
 		{
 			name: "not found",
 			req:  params,
 			mockFunc: func(input dto.TeamMemberUpdateReq) {
-				key := models.KeyCacheTeamMemberDetail(input.ID)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{ID: 0}).Return(false).Once()
-				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID}).Return(nil, nil).Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID, ForUpdate: true}).Return(nil, nil).Once()
 			},
 			wantErr: true,
 		},
@@ -351,10 +532,7 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Update() {
 			name: "email duplicate",
 			req:  params,
 			mockFunc: func(input dto.TeamMemberUpdateReq) {
-				key := models.KeyCacheTeamMemberDetail(input.ID)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{ID: 0}).Return(false).Once()
-				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID}).Return(&srv.teamMember, nil).Once()
-				srv.repo.On("CreateCache", mock.Anything, key, &srv.teamMember, time.Minute).Return().Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID, ForUpdate: true}).Return(&srv.teamMember, nil).Once()
 				// duplicate email
 				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
 					CustomColumn: "id",
@@ -368,10 +546,7 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Update() {
 			name: "failed update record",
 			req:  params,
 			mockFunc: func(input dto.TeamMemberUpdateReq) {
-				key := models.KeyCacheTeamMemberDetail(input.ID)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{ID: 0}).Return(false).Once()
-				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID}).Return(&srv.teamMember, nil).Once()
-				srv.repo.On("CreateCache", mock.Anything, key, &srv.teamMember, time.Minute).Return().Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID, ForUpdate: true}).Return(&srv.teamMember, nil).Once()
 				// Check duplicate
 				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
 					CustomColumn: "id",
@@ -397,10 +572,7 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Update() {
 			name: "success",
 			req:  params,
 			mockFunc: func(input dto.TeamMemberUpdateReq) {
-				key := models.KeyCacheTeamMemberDetail(input.ID)
-				srv.repo.On("GetCache", mock.Anything, key, &models.TeamMember{ID: 0}).Return(false).Once()
-				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID}).Return(&srv.teamMember, nil).Once()
-				srv.repo.On("CreateCache", mock.Anything, key, &srv.teamMember, time.Minute).Return().Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID, ForUpdate: true}).Return(&srv.teamMember, nil).Once()
 				// Check duplicate
 				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
 					CustomColumn: "id",
@@ -419,8 +591,10 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Update() {
 					Email:          input.Email,
 					UsernameGithub: input.UsernameGithub,
 				}).Return(nil).Once()
-				srv.repo.On("DeleteCache", mock.Anything, key).Return().Once()
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{ID: input.ID}).Return(&updated, nil).Once()
+				srv.cache.On("Del", models.KeyCacheTeamMemberDetail(input.ID)).Return(nil).Once()
 			},
+			want:    &updated,
 			wantErr: false,
 		},
 	}
@@ -430,8 +604,13 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Update() {
 				tt.mockFunc(tt.req)
 			}
 
-			if err := srv.service.Update(srv.ctx, tt.req); (err != nil) != tt.wantErr {
+			got, err := srv.service.Update(srv.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("TeamMemberSrv.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TeamMemberSrv.Update() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -442,12 +621,13 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_GetList() {
 		Limit: 1,
 		Page:  1,
 	}
+	estimated := &configs.Configs{App: configs.AppConfig{CursorSecret: testCursorSecret}, DB: configs.DbConfig{CountEstimateThreshold: 1000}}
 
 	tests := []struct {
 		name     string
 		req      dto.TeamMemberListReq
 		mockFunc func(input dto.TeamMemberListReq)
-		want     *response_mapper.Pagination
+		want     *models.Pagination
 		wantErr  bool
 	}{
 		{
@@ -470,7 +650,7 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_GetList() {
 			wantErr: true,
 		},
 		{
-			name: "success total records less than limit",
+			name: "success page not full needs no count",
 			req: dto.TeamMemberListReq{
 				Limit: 10,
 				Page:  1,
@@ -478,54 +658,279 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_GetList() {
 			mockFunc: func(input dto.TeamMemberListReq) {
 				srv.repo.On("GetList", mock.Anything, input).Return(srv.teamMembers, nil).Once()
 			},
-			want: &response_mapper.Pagination{
-				Meta: response_mapper.Meta{
+			want: &models.Pagination{
+				Meta: models.Meta{
 					Page:         1,
 					Limit:        10,
-					TotalRecords: len(srv.teamMembers),
+					TotalRecords: int64(len(srv.teamMembers)),
+					TotalPages:   1,
+				},
+				Data: srv.teamMembers,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success last page not full",
+			req: dto.TeamMemberListReq{
+				Limit: 10,
+				Page:  3,
+			},
+			mockFunc: func(input dto.TeamMemberListReq) {
+				srv.repo.On("GetList", mock.Anything, input).Return(srv.teamMembers, nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         3,
+					Limit:        10,
+					TotalRecords: 22,
+					TotalPages:   3,
+					HasPrev:      true,
+					PrevCursor:   testCursor(srv.teamMembers[0], "id", true),
 				},
 				Data: srv.teamMembers,
 			},
 			wantErr: false,
 		},
 		{
-			name: "failed get total records",
+			name: "failed count",
 			req:  params,
 			mockFunc: func(input dto.TeamMemberListReq) {
 				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[0]}, nil).Once()
-
-				input.CustomColumns = "id"
-				input.IsNotDefaultQuery = true
-				input.Offset = (input.Page - 1) * input.Limit
-				input.Limit = models.DefaultLimitIsTotalDataTrue * input.Limit
-				srv.repo.On("GetList", mock.Anything, input).Return(nil, errors.New("invalid")).Once()
+				srv.repo.On("Count", mock.Anything, input).Return(int64(0), errors.New("invalid")).Once()
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "success total records more than limit",
+			name: "success full page with a next page",
 			req:  params,
 			mockFunc: func(input dto.TeamMemberListReq) {
 				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[0]}, nil).Once()
-
-				input.CustomColumns = "id"
-				input.IsNotDefaultQuery = true
-				input.Offset = (input.Page - 1) * input.Limit
-				input.Limit = models.DefaultLimitIsTotalDataTrue * input.Limit
-
-				total := []models.TeamMember{}
-				for i := 0; i < len(srv.teamMembers); i++ {
-					val := models.TeamMember{ID: srv.teamMembers[i].ID}
-					total = append(total, val)
+				srv.repo.On("Count", mock.Anything, input).Return(int64(len(srv.teamMembers)), nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         1,
+					Limit:        1,
+					TotalRecords: int64(len(srv.teamMembers)),
+					TotalPages:   2,
+					HasNext:      true,
+					NextCursor:   testCursor(srv.teamMembers[0], "id", false),
+				},
+				Data: []models.TeamMember{srv.teamMembers[0]},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success full last page on the boundary",
+			req: dto.TeamMemberListReq{
+				Limit: 1,
+				Page:  2,
+			},
+			mockFunc: func(input dto.TeamMemberListReq) {
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[1]}, nil).Once()
+				srv.repo.On("Count", mock.Anything, input).Return(int64(len(srv.teamMembers)), nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         2,
+					Limit:        1,
+					TotalRecords: int64(len(srv.teamMembers)),
+					TotalPages:   2,
+					HasPrev:      true,
+					PrevCursor:   testCursor(srv.teamMembers[1], "id", true),
+				},
+				Data: []models.TeamMember{srv.teamMembers[1]},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success empty page past the end",
+			req: dto.TeamMemberListReq{
+				Limit: 1,
+				Page:  3,
+			},
+			mockFunc: func(input dto.TeamMemberListReq) {
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{}, nil).Once()
+				srv.repo.On("Count", mock.Anything, input).Return(int64(len(srv.teamMembers)), nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         3,
+					Limit:        1,
+					TotalRecords: int64(len(srv.teamMembers)),
+					TotalPages:   2,
+					HasPrev:      true,
+				},
+				Data: []models.TeamMember{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success selected fields",
+			req: dto.TeamMemberListReq{
+				Limit:  10,
+				Page:   1,
+				Fields: "name",
+			},
+			mockFunc: func(input dto.TeamMemberListReq) {
+				input.Selects = []string{"id", "name"}
+				srv.repo.On("GetList", mock.Anything, input).Return(srv.teamMembers, nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         1,
+					Limit:        10,
+					TotalRecords: int64(len(srv.teamMembers)),
+					TotalPages:   1,
+				},
+				Data: []map[string]json.RawMessage{
+					{"id": json.RawMessage(`1`), "name": json.RawMessage(`"adam"`)},
+					{"id": json.RawMessage(`2`), "name": json.RawMessage(`"adam nasrudin"`)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid sort",
+			req: dto.TeamMemberListReq{
+				Sort: "password",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success search ranked by relevance has no cursors",
+			req: dto.TeamMemberListReq{
+				Limit:  1,
+				Page:   1,
+				Search: "Adam",
+			},
+			mockFunc: func(input dto.TeamMemberListReq) {
+				input.Search = "adam"
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[0]}, nil).Once()
+				srv.repo.On("Count", mock.Anything, input).Return(int64(len(srv.teamMembers)), nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         1,
+					Limit:        1,
+					TotalRecords: int64(len(srv.teamMembers)),
+					TotalPages:   2,
+					HasNext:      true,
+				},
+				Data: []models.TeamMember{srv.teamMembers[0]},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success small table is counted",
+			req:  params,
+			mockFunc: func(input dto.TeamMemberListReq) {
+				srv.service.(*TeamMemberSrv).Cfg = estimated
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[0]}, nil).Once()
+				srv.repo.On("EstimateCount", mock.Anything).Return(int64(-1), nil).Once()
+				srv.repo.On("Count", mock.Anything, input).Return(int64(len(srv.teamMembers)), nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         1,
+					Limit:        1,
+					TotalRecords: int64(len(srv.teamMembers)),
+					TotalPages:   2,
+					HasNext:      true,
+					NextCursor:   testCursor(srv.teamMembers[0], "id", false),
+				},
+				Data: []models.TeamMember{srv.teamMembers[0]},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success large table is estimated",
+			req:  params,
+			mockFunc: func(input dto.TeamMemberListReq) {
+				srv.service.(*TeamMemberSrv).Cfg = estimated
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[0]}, nil).Once()
+				srv.repo.On("EstimateCount", mock.Anything).Return(int64(5000), nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:          1,
+					Limit:         1,
+					TotalRecords:  5000,
+					TotalPages:    5000,
+					HasNext:       true,
+					IsApproximate: true,
+					NextCursor:    testCursor(srv.teamMembers[0], "id", false),
+				},
+				Data: []models.TeamMember{srv.teamMembers[0]},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success search is always counted",
+			req: dto.TeamMemberListReq{
+				Limit:  1,
+				Page:   1,
+				Search: "adam",
+			},
+			mockFunc: func(input dto.TeamMemberListReq) {
+				srv.service.(*TeamMemberSrv).Cfg = estimated
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[0]}, nil).Once()
+				srv.repo.On("Count", mock.Anything, input).Return(int64(1), nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         1,
+					Limit:        1,
+					TotalRecords: 1,
+					TotalPages:   1,
+				},
+				Data: []models.TeamMember{srv.teamMembers[0]},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success filter is always counted",
+			req: dto.TeamMemberListReq{
+				Limit: 1,
+				Page:  1,
+				Query: url.Values{"filter[name][ilike]": {"adam"}},
+			},
+			mockFunc: func(input dto.TeamMemberListReq) {
+				srv.service.(*TeamMemberSrv).Cfg = estimated
+				input.Filter = models.Filter{
+					Conditions: []models.Condition{{Column: "name", Operator: models.FilterIlike, Value: "adam"}},
 				}
-				srv.repo.On("GetList", mock.Anything, input).Return(total, nil).Once()
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[0]}, nil).Once()
+				srv.repo.On("Count", mock.Anything, input).Return(int64(1), nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         1,
+					Limit:        1,
+					TotalRecords: 1,
+					TotalPages:   1,
+				},
+				Data: []models.TeamMember{srv.teamMembers[0]},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success without cursor secret issues no cursor",
+			req:  params,
+			mockFunc: func(input dto.TeamMemberListReq) {
+				srv.service.(*TeamMemberSrv).Cfg = &configs.Configs{}
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[0]}, nil).Once()
+				srv.repo.On("Count", mock.Anything, input).Return(int64(len(srv.teamMembers)), nil).Once()
 			},
-			want: &response_mapper.Pagination{
-				Meta: response_mapper.Meta{
-					Page:         params.Page,
-					Limit:        params.Limit,
-					TotalRecords: len(srv.teamMembers),
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Page:         1,
+					Limit:        1,
+					TotalRecords: int64(len(srv.teamMembers)),
+					TotalPages:   2,
+					HasNext:      true,
 				},
 				Data: []models.TeamMember{srv.teamMembers[0]},
 			},
@@ -549,3 +954,296 @@ func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_GetList() {
 		})
 	}
 }
+
+func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_GetListByCursor() {
+	var (
+		byName    = models.Sort{Column: "name"}
+		after     = srv.teamMember.Cursor(byName, false)
+		before    = srv.teamMembers[1].Cursor(byName, true)
+		afterReq  = func(limit int) dto.TeamMemberListReq { return cursorReq(after, limit) }
+		beforeReq = func(limit int) dto.TeamMemberListReq { return cursorReq(before, limit) }
+		token     = func(c models.Cursor) string {
+			token, _ := utils.EncodeCursor(testCursorSecret, c)
+			return token
+		}
+		third = models.TeamMember{ID: 3, Name: "budi", UsernameGithub: "budi", Email: "budi@example.com"}
+	)
+
+	tests := []struct {
+		name     string
+		req      dto.TeamMemberListReq
+		mockFunc func()
+		want     *models.Pagination
+		wantErr  bool
+	}{
+		{
+			name:    "invalid cursor",
+			req:     dto.TeamMemberListReq{Cursor: "invalid"},
+			wantErr: true,
+		},
+		{
+			name:    "column not allowed",
+			req:     dto.TeamMemberListReq{Cursor: token(models.Cursor{SortBy: "deleted_by", OrderBy: models.OrderByASC})},
+			wantErr: true,
+		},
+		{
+			name:    "sort_by does not match",
+			req:     dto.TeamMemberListReq{Cursor: token(after), SortBy: "email"},
+			wantErr: true,
+		},
+		{
+			name: "failed get records",
+			req:  dto.TeamMemberListReq{Cursor: token(after), Limit: 1},
+			mockFunc: func() {
+				srv.repo.On("GetList", mock.Anything, afterReq(1)).Return(nil, errors.New("invalid")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success forward with a next page",
+			req:  dto.TeamMemberListReq{Cursor: token(after), Limit: 1},
+			mockFunc: func() {
+				srv.repo.On("GetList", mock.Anything, afterReq(1)).Return([]models.TeamMember{srv.teamMembers[1], third}, nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Limit:      1,
+					HasNext:    true,
+					HasPrev:    true,
+					NextCursor: token(srv.teamMembers[1].Cursor(byName, false)),
+					PrevCursor: token(srv.teamMembers[1].Cursor(byName, true)),
+				},
+				Data: []models.TeamMember{srv.teamMembers[1]},
+			},
+		},
+		{
+			name: "success forward last page",
+			req:  dto.TeamMemberListReq{Cursor: token(after), Sort: "name", Limit: 10},
+			mockFunc: func() {
+				input := afterReq(10)
+				input.Sort = "name"
+				srv.repo.On("GetList", mock.Anything, input).Return([]models.TeamMember{srv.teamMembers[1], third}, nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Limit:      10,
+					HasPrev:    true,
+					PrevCursor: token(srv.teamMembers[1].Cursor(byName, true)),
+				},
+				Data: []models.TeamMember{srv.teamMembers[1], third},
+			},
+		},
+		{
+			name: "success backward first page in list order",
+			req:  dto.TeamMemberListReq{Cursor: token(before), Limit: 10},
+			mockFunc: func() {
+				srv.repo.On("GetList", mock.Anything, beforeReq(10)).Return([]models.TeamMember{srv.teamMember}, nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Limit:      10,
+					HasNext:    true,
+					NextCursor: token(srv.teamMember.Cursor(byName, false)),
+				},
+				Data: []models.TeamMember{srv.teamMember},
+			},
+		},
+		{
+			name: "success backward with a previous page",
+			req:  dto.TeamMemberListReq{Cursor: token(third.Cursor(byName, true)), Limit: 1},
+			mockFunc: func() {
+				srv.repo.On("GetList", mock.Anything, cursorReq(third.Cursor(byName, true), 1)).
+					Return([]models.TeamMember{srv.teamMembers[1], srv.teamMember}, nil).Once()
+			},
+			want: &models.Pagination{
+				Meta: models.Meta{
+					Limit:      1,
+					HasNext:    true,
+					HasPrev:    true,
+					NextCursor: token(srv.teamMembers[1].Cursor(byName, false)),
+					PrevCursor: token(srv.teamMembers[1].Cursor(byName, true)),
+				},
+				Data: []models.TeamMember{srv.teamMembers[1]},
+			},
+		},
+	}
+	for _, tt := range tests {
+		srv.T().Run(tt.name, func(t *testing.T) {
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			got, err := srv.service.GetList(srv.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberSrv.GetList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TeamMemberSrv.GetList() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// cursorReq is the request the service passes to the repository when paging by cursor.
+func cursorReq(cursor models.Cursor, limit int) dto.TeamMemberListReq {
+	token, _ := utils.EncodeCursor(testCursorSecret, cursor)
+	return dto.TeamMemberListReq{
+		Cursor: token,
+		Limit:  limit,
+		Page:   1,
+		Sorts:  []models.Sort{cursor.Sort()},
+		Keyset: &cursor,
+	}
+}
+
+func TestTeamMemberSrv_Transaction(t *testing.T) {
+	var (
+		cfg    = &configs.Configs{}
+		logger = driver.Logger(cfg)
+		req    = dto.TeamMemberCreateReq{
+			Name:           "adam",
+			UsernameGithub: "adamnasrudin03",
+			Email:          "adam@example.com",
+		}
+		emailKey    = models.KeyLockTeamMemberEmail(req.Email)
+		usernameKey = models.KeyLockTeamMemberUsernameGithub(req.UsernameGithub)
+	)
+	tests := []struct {
+		name     string
+		mockFunc func(repo *mocks.TeamMemberRepository, tx *mocks.TxManager)
+		wantErr  error
+	}{
+		{
+			name: "failed lock",
+			mockFunc: func(repo *mocks.TeamMemberRepository, tx *mocks.TxManager) {
+				tx.On("WithinTransaction", mock.Anything, mock.Anything).Return(passThroughTransaction).Once()
+				tx.On("Lock", mock.Anything, emailKey, usernameKey).Return(errors.New("invalid")).Once()
+			},
+			wantErr: response_mapper.ErrDB(),
+		},
+		{
+			name: "service error is kept",
+			mockFunc: func(repo *mocks.TeamMemberRepository, tx *mocks.TxManager) {
+				tx.On("WithinTransaction", mock.Anything, mock.Anything).Return(passThroughTransaction).Once()
+				tx.On("Lock", mock.Anything, emailKey, usernameKey).Return(nil).Once()
+				repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn: "id",
+					Email:        req.Email,
+				}).Return(&models.TeamMember{ID: 2}, nil).Once()
+			},
+			wantErr: errDuplicate("email"),
+		},
+		{
+			name: "failed commit",
+			mockFunc: func(repo *mocks.TeamMemberRepository, tx *mocks.TxManager) {
+				tx.On("WithinTransaction", mock.Anything, mock.Anything).Return(errors.New("commit unexpectedly resulted in rollback")).Once()
+			},
+			wantErr: response_mapper.ErrDB(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				repo = &mocks.TeamMemberRepository{}
+				tx   = &mocks.TxManager{}
+				srv  = NewTeamMemberService(repo, tx, &driverMocks.RedisClient{}, cfg, logger, lifecycle.NewManager(0, 0, logger), validator.New())
+			)
+			tt.mockFunc(repo, tx)
+
+			_, err := srv.Create(context.Background(), req)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("TeamMemberSrv.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			tx.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamMemberSrv_GetListCache(t *testing.T) {
+	var (
+		cfg     = &configs.Configs{}
+		logger  = driver.Logger(cfg)
+		members = []models.TeamMember{{ID: 1, Name: "adam", UsernameGithub: "adamnasrudin03", Email: "adam@example.com"}}
+		req     = dto.TeamMemberListReq{Limit: 10, Page: 1}
+		fresh   = &models.Pagination{
+			Meta: models.Meta{Page: 1, Limit: 10, TotalRecords: 1, TotalPages: 1},
+			Data: members,
+		}
+		validated = func() dto.TeamMemberListReq {
+			req := req
+			_ = req.Validate()
+			return req
+		}
+		key = func(generation int64) string {
+			return models.KeyCacheTeamMemberList(generation, validated().Hash())
+		}
+		rendered, _ = json.Marshal(members)
+		cached, _   = json.Marshal(map[string]interface{}{"value": listPage{Meta: fresh.Meta, Data: rendered}})
+	)
+	tests := []struct {
+		name     string
+		noCache  bool
+		mockFunc func(repo *mocks.TeamMemberRepository, cache *driverMocks.RedisClient)
+		want     *models.Pagination
+	}{
+		{
+			name: "hit",
+			mockFunc: func(repo *mocks.TeamMemberRepository, cache *driverMocks.RedisClient) {
+				cache.On("Get", models.KeyCacheTeamMemberListGeneration).Return("3", nil).Once()
+				cache.On("Get", key(3)).Return(string(cached), nil).Once()
+			},
+			want: &models.Pagination{Meta: fresh.Meta, Data: json.RawMessage(rendered)},
+		},
+		{
+			name: "miss caches the page",
+			mockFunc: func(repo *mocks.TeamMemberRepository, cache *driverMocks.RedisClient) {
+				cache.On("Get", models.KeyCacheTeamMemberListGeneration).Return("", driver.ErrNil).Once()
+				cache.On("Get", key(0)).Return("", driver.ErrNil).Once()
+				repo.On("GetList", mock.Anything, mock.Anything).Return(members, nil).Once()
+				cache.On("Set", key(0), mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: fresh,
+		},
+		{
+			name:    "no cache reads the database and refreshes the page",
+			noCache: true,
+			mockFunc: func(repo *mocks.TeamMemberRepository, cache *driverMocks.RedisClient) {
+				cache.On("Get", models.KeyCacheTeamMemberListGeneration).Return("3", nil).Once()
+				repo.On("GetList", mock.Anything, mock.Anything).Return(members, nil).Once()
+				cache.On("Set", key(3), mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: fresh,
+		},
+		{
+			name: "generation unavailable reads the database",
+			mockFunc: func(repo *mocks.TeamMemberRepository, cache *driverMocks.RedisClient) {
+				cache.On("Get", models.KeyCacheTeamMemberListGeneration).Return("", errors.New("redis is down")).Once()
+				repo.On("GetList", mock.Anything, mock.Anything).Return(members, nil).Once()
+			},
+			want: fresh,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				repo  = &mocks.TeamMemberRepository{}
+				cache = &driverMocks.RedisClient{}
+				srv   = NewTeamMemberService(repo, &mocks.TxManager{}, cache, cfg, logger, lifecycle.NewManager(0, 0, logger), validator.New())
+			)
+			tt.mockFunc(repo, cache)
+
+			input := req
+			input.NoCache = tt.noCache
+			got, err := srv.GetList(context.Background(), input)
+			if err != nil {
+				t.Fatalf("TeamMemberSrv.GetList() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TeamMemberSrv.GetList() = %+v, want %+v", got, tt.want)
+			}
+			repo.AssertExpectations(t)
+			cache.AssertExpectations(t)
+		})
+	}
+}
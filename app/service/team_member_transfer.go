@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	help "github.com/adamnasrudin03/go-helpers"
+	response_mapper "github.com/adamnasrudin03/go-helpers/response-mapper/v1"
+	"github.com/adamnasrudin03/go-helpers/validators"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/go-playground/validator/v10"
+)
+
+// exportBatchSize is the number of team members Export reads per query.
+const exportBatchSize = 500
+
+// Import creates the team members read from rows one by one, each in its own transaction,
+// checked by the same rules and duplicate checks as Create. When dryRun is set the rows are
+// only checked, duplicates within the rows included. Failed rows are reported and skipped,
+// only a failure to read rows stops the import.
+func (s *TeamMemberSrv) Import(ctx context.Context, rows dto.TeamMemberReader, dryRun bool) (*dto.TeamMemberImportReport, error) {
+	var (
+		opName = "TeamMemberService-Import"
+		report = &dto.TeamMemberImportReport{DryRun: dryRun, Rows: []dto.TeamMemberImportRow{}}
+		// emails and usernames of the rows checked so far, a dry run creates nothing to check them against
+		seen = map[string]bool{}
+	)
+	defer func() {
+		// once for the whole file, the rows created before a failure are committed too
+		if report.Created > 0 {
+			s.invalidateLists()
+		}
+	}()
+
+	for {
+		req, err := rows.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		report.Total++
+		row := dto.TeamMemberImportRow{Row: report.Total}
+		if err == nil {
+			err = s.validate(req)
+		}
+		if err != nil && !errors.Is(err, dto.ErrInvalidRow) && !isValidationError(err) {
+			s.Logger.Errorf("%s, failed read row %d: %v", opName, row.Row, err)
+			return nil, response_mapper.NewError(response_mapper.ErrValidation, err)
+		}
+
+		switch {
+		case err != nil:
+		case dryRun:
+			email, username := "email:"+help.ToLower(req.Email), "username_github:"+help.ToLower(req.UsernameGithub)
+			switch {
+			case seen[email]:
+				err = errDuplicate("email")
+			case seen[username]:
+				err = errDuplicate("username_github")
+			default:
+				err = s.checkDuplicate(ctx, dto.TeamMemberDetailReq{
+					Email:          help.ToLower(req.Email),
+					UsernameGithub: help.ToLower(req.UsernameGithub),
+				})
+			}
+			seen[email], seen[username] = true, true
+		default:
+			var member *models.TeamMember
+			member, err = s.create(ctx, req)
+			if err == nil {
+				row.ID = member.ID
+			}
+		}
+
+		switch {
+		case err != nil:
+			row.Status, row.Error = dto.ImportStatusFailed, errorMessage(err)
+		case dryRun:
+			row.Status = dto.ImportStatusValid
+		default:
+			row.Status = dto.ImportStatusCreated
+		}
+		report.Add(row)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	return report, nil
+}
+
+// Export calls fn with every team member of the list filtered by req in id order, reading
+// them by keyset in batches so the list may be of any size. req sort, fields and paging are ignored.
+func (s *TeamMemberSrv) Export(ctx context.Context, req dto.TeamMemberListReq, fn func(member models.TeamMember) error) error {
+	var (
+		opName = "TeamMemberService-Export"
+		sort   = models.Sort{Column: "id"}
+	)
+	req.Sort, req.SortBy, req.OrderBy, req.Fields, req.Cursor = "", "", "", "", ""
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	req.Limit, req.IsNoLimit, req.Sorts = exportBatchSize, false, []models.Sort{sort}
+	cursor := models.TeamMember{}.Cursor(sort, false)
+	for {
+		req.Keyset = &cursor
+		data, err := s.Repo.GetList(ctx, req)
+		if err != nil {
+			s.Logger.Errorf("%s, failed get list: %v", opName, err)
+			return response_mapper.ErrDB()
+		}
+
+		hasMore := len(data) > req.Limit
+		if hasMore {
+			data = data[:req.Limit]
+		}
+		for _, member := range data {
+			if err := fn(member); err != nil {
+				return err
+			}
+		}
+		if !hasMore {
+			return nil
+		}
+
+		cursor = data[len(data)-1].Cursor(sort, false)
+	}
+}
+
+// validate checks req with the rules of its validate tags, only those of fields when given.
+func (s *TeamMemberSrv) validate(req interface{}, fields ...string) error {
+	var err error
+	if len(fields) > 0 {
+		err = s.Validate.StructPartial(req, fields...)
+	} else {
+		err = s.Validate.Struct(req)
+	}
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		msg := strings.Join(validators.FormatErrorValidator(fieldErrs), ", ")
+		return response_mapper.NewError(response_mapper.ErrValidation, response_mapper.NewResponseMultiLang(
+			response_mapper.MultiLanguages{
+				ID: msg,
+				EN: msg,
+			},
+		))
+	}
+
+	return err
+}
+
+func isValidationError(err error) bool {
+	var respErr *response_mapper.ResponseError
+	return errors.As(err, &respErr) && respErr.Code == int(response_mapper.ErrValidation)
+}
+
+// errorMessage is the English message of err.
+func errorMessage(err error) string {
+	var respErr *response_mapper.ResponseError
+	if errors.As(err, &respErr) && respErr.Message.EN != "" {
+		return respErr.Message.EN
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// sliceReader reads rows, returning the error of a row instead when it has one.
+type sliceReader struct {
+	rows []dto.TeamMemberCreateReq
+	errs map[int]error
+	read int
+}
+
+func (r *sliceReader) Read() (dto.TeamMemberCreateReq, error) {
+	if r.read == len(r.rows) {
+		return dto.TeamMemberCreateReq{}, io.EOF
+	}
+	r.read++
+	if err := r.errs[r.read-1]; err != nil {
+		return dto.TeamMemberCreateReq{}, err
+	}
+	return r.rows[r.read-1], nil
+}
+
+func (srv *TeamMemberServiceTestSuite) mockNoDuplicate(email, usernameGithub string) {
+	srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+		CustomColumn: "id",
+		Email:        email,
+	}).Return(nil, nil).Once()
+	srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+		CustomColumn:   "id",
+		UsernameGithub: usernameGithub,
+	}).Return(nil, nil).Once()
+}
+
+func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Import() {
+	var (
+		adam    = dto.TeamMemberCreateReq{Name: "Adam", UsernameGithub: "Adam", Email: "adam@example.com"}
+		invalid = dto.TeamMemberCreateReq{Name: "Invalid", UsernameGithub: "invalid", Email: "not-an-email"}
+		taken   = dto.TeamMemberCreateReq{Name: "Taken", UsernameGithub: "taken", Email: "taken@example.com"}
+	)
+	tests := []struct {
+		name     string
+		rows     *sliceReader
+		dryRun   bool
+		mockFunc func()
+		want     *dto.TeamMemberImportReport
+		wantErr  bool
+	}{
+		{
+			name: "creates valid rows and reports the others",
+			rows: &sliceReader{
+				rows: []dto.TeamMemberCreateReq{adam, invalid, {}, taken},
+				errs: map[int]error{2: dto.ErrInvalidRow},
+			},
+			mockFunc: func() {
+				srv.mockNoDuplicate("adam@example.com", "adam")
+				srv.repo.On("Create", mock.Anything, &models.TeamMember{
+					Name:           "Adam",
+					Email:          "adam@example.com",
+					UsernameGithub: "adam",
+				}).Return(&models.TeamMember{ID: 7}, nil).Once()
+				srv.cache.On("Del", models.KeyCacheTeamMemberDetail(7)).Return(nil).Once()
+
+				srv.repo.On("GetDetail", mock.Anything, dto.TeamMemberDetailReq{
+					CustomColumn: "id",
+					Email:        "taken@example.com",
+				}).Return(&models.TeamMember{ID: 1}, nil).Once()
+			},
+			want: &dto.TeamMemberImportReport{
+				Total:   4,
+				Created: 1,
+				Failed:  3,
+				Rows: []dto.TeamMemberImportRow{
+					{Row: 1, Status: dto.ImportStatusCreated, ID: 7},
+					{Row: 2, Status: dto.ImportStatusFailed, Error: "Email must be an email address"},
+					{Row: 3, Status: dto.ImportStatusFailed, Error: "invalid row"},
+					{Row: 4, Status: dto.ImportStatusFailed, Error: "email already exists"},
+				},
+			},
+		},
+		{
+			name:   "dry run checks duplicates within the rows",
+			rows:   &sliceReader{rows: []dto.TeamMemberCreateReq{adam, {Name: "Adam 2", UsernameGithub: "adam", Email: "adam2@example.com"}}},
+			dryRun: true,
+			mockFunc: func() {
+				srv.mockNoDuplicate("adam@example.com", "adam")
+			},
+			want: &dto.TeamMemberImportReport{
+				DryRun: true,
+				Total:  2,
+				Valid:  1,
+				Failed: 1,
+				Rows: []dto.TeamMemberImportRow{
+					{Row: 1, Status: dto.ImportStatusValid},
+					{Row: 2, Status: dto.ImportStatusFailed, Error: "username_github already exists"},
+				},
+			},
+		},
+		{
+			name:    "failed read",
+			rows:    &sliceReader{rows: []dto.TeamMemberCreateReq{adam}, errs: map[int]error{0: errors.New("csv header: missing column email")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		srv.T().Run(tt.name, func(t *testing.T) {
+			if tt.mockFunc != nil {
+				tt.mockFunc()
+			}
+
+			got, err := srv.service.Import(srv.ctx, tt.rows, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberSrv.Import() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TeamMemberSrv.Import() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func (srv *TeamMemberServiceTestSuite) TestTeamMemberSrv_Export() {
+	batch := make([]models.TeamMember, exportBatchSize+1)
+	for i := range batch {
+		batch[i] = models.TeamMember{ID: uint64(i + 1)}
+	}
+	isKeysetAfter := func(id uint64) interface{} {
+		return mock.MatchedBy(func(req dto.TeamMemberListReq) bool {
+			return req.Keyset != nil && req.Keyset.SortBy == "id" && req.Keyset.ID == id &&
+				req.Limit == exportBatchSize && !req.IsNoLimit && req.Search == "adam"
+		})
+	}
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantIDs  int
+		wantErr  bool
+	}{
+		{
+			name: "reads batches until the last one",
+			mockFunc: func() {
+				srv.repo.On("GetList", mock.Anything, isKeysetAfter(0)).Return(batch, nil).Once()
+				srv.repo.On("GetList", mock.Anything, isKeysetAfter(exportBatchSize)).Return(srv.teamMembers[:1], nil).Once()
+			},
+			wantIDs: exportBatchSize + 1,
+		},
+		{
+			name: "failed get list",
+			mockFunc: func() {
+				srv.repo.On("GetList", mock.Anything, isKeysetAfter(0)).Return(nil, errors.New("invalid")).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		srv.T().Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			var got []models.TeamMember
+			err := srv.service.Export(srv.ctx, dto.TeamMemberListReq{Search: "adam", Sort: "-name", Limit: 5}, func(member models.TeamMember) error {
+				got = append(got, member)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TeamMemberSrv.Export() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantIDs {
+				t.Errorf("TeamMemberSrv.Export() exported %d, want %d", len(got), tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+)
+
+// runCache runs the cache subcommand, args are the arguments following "cache".
+func runCache(args []string, out io.Writer) error {
+	_, args, err := subcommand("cache", args, "flush")
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("cache flush", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.Usage = func() {
+		fmt.Fprintf(out, "usage: go-skeleton cache flush [pattern ...]\n\ndeletes the keys matching the patterns, by default those of the application: %v\n", models.CacheKeyPatterns)
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = models.CacheKeyPatterns
+	}
+
+	cache := driver.Redis(configs.GetInstance())
+	defer cache.Close()
+
+	for _, pattern := range patterns {
+		deleted, err := cache.DelPattern(pattern)
+		if err != nil {
+			return fmt.Errorf("flush %s: %w", pattern, err)
+		}
+		fmt.Fprintf(out, "%s: %d key(s) deleted\n", pattern, deleted)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const usage = `usage: go-skeleton [command] [arguments]
+
+commands:
+  serve                                serve the API, the default without a command
+  migrate up|down|status|create        manage the database schema
+  seed [list|fake]                     fill the database with the seeders of APP_ENV
+  config print                         print the configuration, secrets redacted
+  cache flush [pattern ...]            delete the cached keys of the application
+  team-members import|export           import or export team members as CSV or JSON Lines
+  api-keys create                      create an API key and print it once
+
+Run "go-skeleton <command> -h" for the flags of a command.
+`
+
+// commands are the subcommands of the binary, each run with the arguments following its name.
+var commands = map[string]func(args []string, out io.Writer) error{
+	"serve":        runServe,
+	"migrate":      runMigrate,
+	"seed":         runSeed,
+	"config":       runConfig,
+	"cache":        runCache,
+	"team-members": runTeamMembers,
+	"api-keys":     runApiKeys,
+}
+
+// run runs the command named by args[0], it serves the API when args is empty.
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return runServe(nil, out)
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(out, usage)
+		return nil
+	}
+
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(out, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	return command(args[1:], out)
+}
+
+// subcommand returns the name of the subcommand of a command and the arguments following it,
+// it fails with the usage of the command when args does not start with one of names.
+func subcommand(command string, args []string, names ...string) (string, []string, error) {
+	if len(args) > 0 {
+		for _, name := range names {
+			if args[0] == name {
+				return name, args[1:], nil
+			}
+		}
+	}
+
+	return "", nil, fmt.Errorf("usage: go-skeleton %s %s", command, strings.Join(names, "|"))
+}
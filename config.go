@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+)
+
+// runConfig runs the config subcommand, args are the arguments following "config".
+func runConfig(args []string, out io.Writer) error {
+	_, args, err := subcommand("config", args, "print")
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("config print", flag.ContinueOnError)
+	flags.SetOutput(out)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(configs.GetInstance().Redacted())
+}
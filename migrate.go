@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/database"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/migrate"
+)
+
+const migrateUsage = `usage: go-skeleton migrate <command> [flags]
+
+commands:
+  up [-steps N]             apply pending migrations, all of them by default
+  down [-steps N]           revert the last applied migrations, 1 by default
+  status                    list migrations and when they were applied
+  create [-dir DIR] <name>  write empty up and down scripts of a new migration
+`
+
+// runMigrate runs the migrate subcommand, args are the arguments following "migrate".
+func runMigrate(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(out, migrateUsage)
+		return errors.New("migrate command is required")
+	}
+
+	var (
+		command = args[0]
+		flags   = flag.NewFlagSet("migrate "+command, flag.ContinueOnError)
+		steps   = flags.Int("steps", 0, "number of migrations to apply or revert")
+		dir     = flags.String("dir", "pkg/database/migrations", "directory of the migration files")
+	)
+	flags.SetOutput(out)
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if command == "create" {
+		if flags.NArg() != 1 {
+			return errors.New("migrate create: migration name is required")
+		}
+		paths, err := migrate.Create(*dir, flags.Arg(0), time.Now())
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			fmt.Fprintf(out, "created %s\n", path)
+		}
+		return nil
+	}
+
+	var (
+		ctx      = context.Background()
+		cfg      = configs.GetInstance()
+		logger   = driver.Logger(cfg)
+		db       = database.OpenDbConnection(cfg, logger)
+		migrator = database.NewMigrator(db, logger)
+	)
+	defer database.CloseDbConnection(db, logger)
+
+	switch command {
+	case "up":
+		done, err := migrator.Up(ctx, *steps)
+		fmt.Fprintf(out, "%d migration(s) applied\n", len(done))
+		return err
+	case "down":
+		if *steps <= 0 {
+			*steps = 1
+		}
+		done, err := migrator.Down(ctx, *steps)
+		fmt.Fprintf(out, "%d migration(s) reverted\n", len(done))
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printMigrateStatus(out, statuses)
+	default:
+		fmt.Fprint(out, migrateUsage)
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+}
+
+func printMigrateStatus(out io.Writer, statuses []migrate.Status) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.Missing {
+			appliedAt += " (file missing)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+
+	return w.Flush()
+}
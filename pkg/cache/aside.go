@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"golang.org/x/sync/singleflight"
+)
+
+// metrics holds the Stats of every published Aside by name, served with the other
+// expvars on /debug/vars.
+var metrics = expvar.NewMap("cache")
+
+// Options tune an Aside, Name labels its metrics.
+type Options struct {
+	Name string
+	// TTL of a loaded value, stretched by up to Jitter (0.1 for 10%) so that keys
+	// cached at the same time do not all expire at the same time.
+	TTL    time.Duration
+	Jitter float64
+	// NegativeTTL of the entry remembering that a key has no value, none is cached when 0.
+	NegativeTTL time.Duration
+}
+
+// Stats counts the lookups of an Aside, Error counts the failed Redis calls.
+type Stats struct {
+	Hit   int64 `json:"hit"`
+	Miss  int64 `json:"miss"`
+	Error int64 `json:"error"`
+}
+
+// entry is stored under a key, Missing marks a key known to have no value.
+type entry[T any] struct {
+	Value   *T   `json:"value,omitempty"`
+	Missing bool `json:"missing,omitempty"`
+}
+
+// Aside caches the values of T in Redis in front of a loader, such as a repository lookup.
+type Aside[T any] struct {
+	client driver.RedisClient
+	opts   Options
+	group  singleflight.Group
+
+	hit  expvar.Int
+	miss expvar.Int
+	fail expvar.Int
+}
+
+func New[T any](client driver.RedisClient, opts Options) *Aside[T] {
+	return &Aside[T]{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// Publish serves the Stats of a in the "cache" map of /debug/vars under its Name, it is
+// called once when the application is wired and panics when the Name is already taken,
+// so that two caches never report each other's counters.
+func (a *Aside[T]) Publish() {
+	if a.opts.Name == "" || metrics.Get(a.opts.Name) != nil {
+		panic(fmt.Sprintf("cache: metrics name %q is empty or already published", a.opts.Name))
+	}
+
+	metrics.Set(a.opts.Name, expvar.Func(func() any {
+		return a.Stats()
+	}))
+}
+
+// Get returns the value cached under key or loads it with load and caches it, concurrent
+// misses of a key share one load. A load returning nil without an error means the key
+// has no value, which is cached for NegativeTTL and makes Get return nil, nil. Errors of
+// load are returned and not cached, and a failing Redis is treated as a miss.
+func (a *Aside[T]) Get(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	value, ok := a.lookup(key)
+	if ok {
+		a.hit.Add(1)
+		return value, nil
+	}
+	a.miss.Add(1)
+
+	res, err, _ := a.group.Do(key, func() (interface{}, error) {
+		// the callers sharing the load must not be failed by the one that started it leaving
+		value, err := load(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		a.store(key, value)
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	value = res.(*T)
+	if value == nil {
+		return nil, nil
+	}
+	// each caller gets its own copy of the shared value
+	clone := *value
+	return &clone, nil
+}
+
+// Refresh loads the value of key with load without reading the cache, for a caller asking
+// for a fresh value, and caches it for the next Get.
+func (a *Aside[T]) Refresh(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	value, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.store(key, value)
+	return value, nil
+}
+
+// Delete removes the entry of key, the next Get loads it again.
+func (a *Aside[T]) Delete(key string) error {
+	err := a.client.Del(key)
+	if err != nil {
+		a.fail.Add(1)
+	}
+	return err
+}
+
+func (a *Aside[T]) Stats() Stats {
+	return Stats{
+		Hit:   a.hit.Value(),
+		Miss:  a.miss.Value(),
+		Error: a.fail.Value(),
+	}
+}
+
+func (a *Aside[T]) lookup(key string) (*T, bool) {
+	data, err := a.client.Get(key)
+	if err != nil {
+		if !errors.Is(err, driver.ErrNil) {
+			a.fail.Add(1)
+		}
+		return nil, false
+	}
+
+	var e entry[T]
+	err = json.Unmarshal([]byte(data), &e)
+	if err != nil || (e.Value == nil && !e.Missing) {
+		// written in another format, load it again
+		return nil, false
+	}
+
+	return e.Value, true
+}
+
+func (a *Aside[T]) store(key string, value *T) {
+	ttl := a.opts.NegativeTTL
+	if value != nil {
+		ttl = a.opts.TTL + time.Duration(rand.Float64()*a.opts.Jitter*float64(a.opts.TTL))
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	err := a.client.Set(key, entry[T]{Value: value, Missing: value == nil}, ttl)
+	if err != nil {
+		a.fail.Add(1)
+	}
+}
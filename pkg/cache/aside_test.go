@@ -0,0 +1,238 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+type member struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestAside_Get(t *testing.T) {
+	var (
+		key     = "member_1"
+		adam    = &member{ID: 1, Name: "adam"}
+		errLoad = errors.New("db is down")
+		opts    = Options{Name: "test_member", TTL: time.Minute, Jitter: 0.1, NegativeTTL: 10 * time.Second}
+		jitter  = mock.MatchedBy(func(ttl time.Duration) bool {
+			return ttl >= time.Minute && ttl <= time.Minute+6*time.Second
+		})
+	)
+	tests := []struct {
+		name      string
+		mockFunc  func(client *mocks.RedisClient)
+		load      func(ctx context.Context) (*member, error)
+		want      *member
+		wantErr   bool
+		wantLoads int32
+		wantStats Stats
+	}{
+		{
+			name: "hit",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", key).Return(`{"value":{"id":1,"name":"adam"}}`, nil).Once()
+			},
+			want:      adam,
+			wantStats: Stats{Hit: 1},
+		},
+		{
+			name: "negative hit",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", key).Return(`{"missing":true}`, nil).Once()
+			},
+			wantStats: Stats{Hit: 1},
+		},
+		{
+			name: "miss caches the loaded value with jitter",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", key).Return("", driver.ErrNil).Once()
+				client.On("Set", key, entry[member]{Value: adam}, jitter).Return(nil).Once()
+			},
+			load: func(ctx context.Context) (*member, error) {
+				return adam, nil
+			},
+			want:      adam,
+			wantLoads: 1,
+			wantStats: Stats{Miss: 1},
+		},
+		{
+			name: "miss caches a negative entry",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", key).Return("", driver.ErrNil).Once()
+				client.On("Set", key, entry[member]{Missing: true}, 10*time.Second).Return(nil).Once()
+			},
+			load: func(ctx context.Context) (*member, error) {
+				return nil, nil
+			},
+			wantLoads: 1,
+			wantStats: Stats{Miss: 1},
+		},
+		{
+			name: "entry in another format is loaded again",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", key).Return(`{"id":1,"name":"adam"}`, nil).Once()
+				client.On("Set", key, entry[member]{Value: adam}, jitter).Return(nil).Once()
+			},
+			load: func(ctx context.Context) (*member, error) {
+				return adam, nil
+			},
+			want:      adam,
+			wantLoads: 1,
+			wantStats: Stats{Miss: 1},
+		},
+		{
+			name: "load error is not cached",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", key).Return("", driver.ErrNil).Once()
+			},
+			load: func(ctx context.Context) (*member, error) {
+				return nil, errLoad
+			},
+			wantErr:   true,
+			wantLoads: 1,
+			wantStats: Stats{Miss: 1},
+		},
+		{
+			name: "redis down is a miss",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", key).Return("", errors.New("connection refused")).Once()
+				client.On("Set", key, entry[member]{Value: adam}, jitter).Return(errors.New("connection refused")).Once()
+			},
+			load: func(ctx context.Context) (*member, error) {
+				return adam, nil
+			},
+			want:      adam,
+			wantLoads: 1,
+			wantStats: Stats{Miss: 1, Error: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				client = &mocks.RedisClient{}
+				aside  = New[member](client, opts)
+				loads  int32
+			)
+			tt.mockFunc(client)
+
+			got, err := aside.Get(context.Background(), key, func(ctx context.Context) (*member, error) {
+				atomic.AddInt32(&loads, 1)
+				return tt.load(ctx)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Aside.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Aside.Get() = %+v, want %+v", got, tt.want)
+			}
+			if loads != tt.wantLoads {
+				t.Errorf("Aside.Get() loads = %v, want %v", loads, tt.wantLoads)
+			}
+			if stats := aside.Stats(); stats != tt.wantStats {
+				t.Errorf("Aside.Stats() = %+v, want %+v", stats, tt.wantStats)
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAside_GetCoalesces(t *testing.T) {
+	var (
+		client  = &mocks.RedisClient{}
+		aside   = New[member](client, Options{Name: "test_coalesce", TTL: time.Minute})
+		adam    = &member{ID: 1, Name: "adam"}
+		callers = 10
+		loads   int32
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	client.On("Get", "member_1").Return("", driver.ErrNil)
+	client.On("Set", "member_1", mock.Anything, time.Minute).Return(nil).Once()
+
+	results := make([]*member, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = aside.Get(context.Background(), "member_1", func(ctx context.Context) (*member, error) {
+				atomic.AddInt32(&loads, 1)
+				<-release
+				return adam, nil
+			})
+		}(i)
+	}
+	// let every caller miss and join the shared load before it returns
+	for aside.Stats().Miss < int64(callers) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads != 1 {
+		t.Errorf("Aside.Get() loads = %v, want 1", loads)
+	}
+	for i, got := range results {
+		if !reflect.DeepEqual(got, adam) {
+			t.Errorf("Aside.Get() caller %d = %+v, want %+v", i, got, adam)
+		}
+		if got == adam || (i > 0 && got == results[0]) {
+			t.Errorf("Aside.Get() caller %d shares the loaded value", i)
+		}
+	}
+	client.AssertExpectations(t)
+}
+
+func TestAside_Refresh(t *testing.T) {
+	var (
+		client = &mocks.RedisClient{}
+		aside  = New[member](client, Options{Name: "test_refresh", TTL: time.Minute})
+		adam   = &member{ID: 1, Name: "adam"}
+	)
+	client.On("Set", "member_1", entry[member]{Value: adam}, time.Minute).Return(nil).Once()
+
+	got, err := aside.Refresh(context.Background(), "member_1", func(ctx context.Context) (*member, error) {
+		return adam, nil
+	})
+	if err != nil {
+		t.Fatalf("Aside.Refresh() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, adam) {
+		t.Errorf("Aside.Refresh() = %+v, want %+v", got, adam)
+	}
+	// the cached entry is not read
+	client.AssertNotCalled(t, "Get", mock.Anything)
+	client.AssertExpectations(t)
+}
+
+func TestAside_Publish(t *testing.T) {
+	var (
+		client = &mocks.RedisClient{}
+		aside  = New[member](client, Options{Name: "test_publish", TTL: time.Minute})
+	)
+	client.On("Del", "member_1").Return(errors.New("connection refused")).Once()
+	aside.Publish()
+	_ = aside.Delete("member_1")
+
+	if got := metrics.Get("test_publish").String(); got != `{"hit":0,"miss":0,"error":1}` {
+		t.Errorf("metrics of test_publish = %s", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Aside.Publish() of a published name did not panic")
+		}
+	}()
+	New[member](client, Options{Name: "test_publish"}).Publish()
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+)
+
+// Generation versions a group of entries, such as the pages of a list. Their keys embed
+// the current generation and Bump moves every reader to new keys at once, leaving the
+// entries of the old generation to expire instead of looking them up to delete them.
+type Generation struct {
+	client driver.RedisClient
+	key    string
+}
+
+func NewGeneration(client driver.RedisClient, key string) *Generation {
+	return &Generation{
+		client: client,
+		key:    key,
+	}
+}
+
+// Current returns the generation, 0 before the first Bump.
+func (g *Generation) Current() (int64, error) {
+	data, err := g.client.Get(g.key)
+	if errors.Is(err, driver.ErrNil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(data, 10, 64)
+}
+
+// Bump starts a new generation, it must run after the change that outdates the entries
+// is committed or a reader may cache the old data under the new generation.
+func (g *Generation) Bump() error {
+	_, err := g.client.Incr(g.key)
+	return err
+}
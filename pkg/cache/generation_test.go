@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver/mocks"
+)
+
+func TestGeneration_Current(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockFunc func(client *mocks.RedisClient)
+		want     int64
+		wantErr  bool
+	}{
+		{
+			name: "never bumped",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", "list_generation").Return("", driver.ErrNil).Once()
+			},
+			want: 0,
+		},
+		{
+			name: "bumped",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", "list_generation").Return("42", nil).Once()
+			},
+			want: 42,
+		},
+		{
+			name: "redis down",
+			mockFunc: func(client *mocks.RedisClient) {
+				client.On("Get", "list_generation").Return("", errors.New("connection refused")).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.RedisClient{}
+			tt.mockFunc(client)
+
+			got, err := NewGeneration(client, "list_generation").Current()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Generation.Current() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Generation.Current() = %v, want %v", got, tt.want)
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGeneration_Bump(t *testing.T) {
+	client := &mocks.RedisClient{}
+	client.On("Incr", "list_generation").Return(int64(43), nil).Once()
+
+	err := NewGeneration(client, "list_generation").Bump()
+	if err != nil {
+		t.Errorf("Generation.Bump() error = %v", err)
+	}
+	client.AssertExpectations(t)
+}
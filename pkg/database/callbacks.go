@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"gorm.io/gorm"
+)
+
+const (
+	columnCreatedBy = "created_by"
+	columnUpdatedBy = "updated_by"
+)
+
+// RegisterCallbacks stamps models.DefaultModel CreatedBy/UpdatedBy with the
+// caller carried by the statement context, see models.ContextWithCaller.
+func RegisterCallbacks(db *gorm.DB) error {
+	err := db.Callback().Create().Before("gorm:create").Register("app:stamp_created_by", stampCreatedBy)
+	if err != nil {
+		return err
+	}
+
+	return db.Callback().Update().Before("gorm:update").Register("app:stamp_updated_by", stampUpdatedBy)
+}
+
+func stampCreatedBy(db *gorm.DB) {
+	stampColumns(db, columnCreatedBy, columnUpdatedBy)
+}
+
+func stampUpdatedBy(db *gorm.DB) {
+	stampColumns(db, columnUpdatedBy)
+}
+
+func stampColumns(db *gorm.DB, columns ...string) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	caller, ok := models.CallerFromContext(db.Statement.Context)
+	if !ok {
+		return
+	}
+
+	for _, column := range columns {
+		if db.Statement.Schema.LookUpField(column) == nil {
+			continue
+		}
+		db.Statement.SetColumn(column, caller.ID, true)
+	}
+}
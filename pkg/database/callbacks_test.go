@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/middlewares"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/repository"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunConn lets transactions begin and commit without a live database,
+// statements are never sent to it in dry run mode.
+type dryRunConn struct{}
+
+func (dryRunConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New("dry run")
+}
+
+func (dryRunConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errors.New("dry run")
+}
+
+func (dryRunConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("dry run")
+}
+
+func (dryRunConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (c dryRunConn) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return c, nil
+}
+
+func (dryRunConn) Commit() error   { return nil }
+func (dryRunConn) Rollback() error { return nil }
+
+// newDryRunDB opens a gorm DB that builds statements without a live database.
+func newDryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: dryRunConn{},
+	}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("failed open dry run db: %v", err)
+	}
+
+	err = RegisterCallbacks(db)
+	if err != nil {
+		t.Fatalf("failed register callbacks: %v", err)
+	}
+
+	return db
+}
+
+func TestRegisterCallbacks_FromRequestToRow(t *testing.T) {
+	var (
+		cfg    = &configs.Configs{}
+		logger = driver.Logger(cfg)
+		jwt    = middlewares.NewJWTAuthenticator("secret", "", 0)
+		repo   = repository.NewTeamMemberRepository(newDryRunDB(t), cfg, logger)
+	)
+
+	tests := []struct {
+		name          string
+		caller        uint64
+		method        string
+		wantCreatedBy uint64
+		wantUpdatedBy uint64
+	}{
+		{
+			name:          "create stamps created_by and updated_by",
+			caller:        7,
+			method:        http.MethodPost,
+			wantCreatedBy: 7,
+			wantUpdatedBy: 7,
+		},
+		{
+			name:          "update stamps updated_by only",
+			caller:        8,
+			method:        http.MethodPut,
+			wantCreatedBy: 0,
+			wantUpdatedBy: 8,
+		},
+		{
+			name:          "anonymous create leaves columns empty",
+			caller:        0,
+			method:        http.MethodPost,
+			wantCreatedBy: 0,
+			wantUpdatedBy: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := &models.TeamMember{
+				ID:             1,
+				Name:           "adam",
+				UsernameGithub: "adamnasrudin03",
+				Email:          "adam@example.com",
+			}
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				var err error
+				switch r.Method {
+				case http.MethodPost:
+					_, err = repo.Create(r.Context(), row)
+				case http.MethodPut:
+					err = repo.Update(r.Context(), row)
+				}
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}
+
+			r := httptest.NewRequest(tt.method, "/v1/team-members", nil)
+			w := httptest.NewRecorder()
+			if tt.caller > 0 {
+				token, err := jwt.Sign(middlewares.JWTClaims{
+					Subject:   strconv.FormatUint(tt.caller, 10),
+					ExpiresAt: time.Now().Add(time.Hour).Unix(),
+				})
+				if err != nil {
+					t.Fatalf("failed sign token: %v", err)
+				}
+				r.Header.Set("Authorization", "Bearer "+token)
+				middlewares.Authenticate(handler, jwt).ServeHTTP(w, r)
+			} else {
+				handler(w, r)
+			}
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("handler status = %v, want %v", w.Code, http.StatusOK)
+			}
+			if row.CreatedBy != tt.wantCreatedBy {
+				t.Errorf("TeamMember.CreatedBy = %v, want %v", row.CreatedBy, tt.wantCreatedBy)
+			}
+			if row.UpdatedBy != tt.wantUpdatedBy {
+				t.Errorf("TeamMember.UpdatedBy = %v, want %v", row.UpdatedBy, tt.wantUpdatedBy)
+			}
+		})
+	}
+}
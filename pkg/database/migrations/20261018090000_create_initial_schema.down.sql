@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS api_keys;
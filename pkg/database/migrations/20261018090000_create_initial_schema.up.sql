@@ -0,0 +1,68 @@
+-- Matches the schema AutoMigrate created, so databases migrated before
+-- versioned migrations existed are adopted as they are.
+CREATE TABLE IF NOT EXISTS api_keys (
+	id bigserial PRIMARY KEY,
+	name text NOT NULL,
+	owner_id bigint NOT NULL,
+	role text NOT NULL DEFAULT 'viewer',
+	prefix text NOT NULL,
+	key_hash text NOT NULL,
+	expired_at timestamptz,
+	revoked_at timestamptz,
+	created_by bigint,
+	created_at timestamptz,
+	updated_by bigint,
+	updated_at timestamptz,
+	deleted_by bigint,
+	deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_owner_id ON api_keys (owner_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys (key_hash);
+CREATE INDEX IF NOT EXISTS idx_api_keys_deleted_at ON api_keys (deleted_at);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+	id bigserial PRIMARY KEY,
+	actor_id bigint,
+	actor_method text,
+	action text NOT NULL,
+	entity text NOT NULL,
+	entity_id bigint,
+	changes jsonb,
+	request_id text,
+	created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity, entity_id);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+	id bigserial PRIMARY KEY,
+	role text NOT NULL,
+	permission text NOT NULL,
+	created_by bigint,
+	created_at timestamptz,
+	updated_by bigint,
+	updated_at timestamptz,
+	deleted_by bigint,
+	deleted_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_role_permissions_role_permission ON role_permissions (role, permission);
+CREATE INDEX IF NOT EXISTS idx_role_permissions_deleted_at ON role_permissions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS team_members (
+	id bigserial PRIMARY KEY,
+	name text NOT NULL,
+	username_github text NOT NULL,
+	email text NOT NULL,
+	created_by bigint,
+	created_at timestamptz,
+	updated_by bigint,
+	updated_at timestamptz,
+	deleted_by bigint,
+	deleted_at timestamptz
+);
+-- unique indexes ignore soft deleted rows, drop the ones created before soft delete existed
+DROP INDEX IF EXISTS idx_team_members_email;
+DROP INDEX IF EXISTS idx_team_members_username_github;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_members_email_active ON team_members (email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_members_username_github_active ON team_members (username_github) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_team_members_deleted_at ON team_members (deleted_at);
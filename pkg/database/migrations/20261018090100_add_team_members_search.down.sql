@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_team_members_search_vector;
+ALTER TABLE team_members DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,3 @@
+ALTER TABLE team_members ADD COLUMN IF NOT EXISTS search_vector tsvector
+	GENERATED ALWAYS AS (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(username_github, '') || ' ' || coalesce(email, ''))) STORED;
+CREATE INDEX IF NOT EXISTS idx_team_members_search_vector ON team_members USING GIN (search_vector);
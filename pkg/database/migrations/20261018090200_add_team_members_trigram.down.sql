@@ -0,0 +1,3 @@
+-- the pg_trgm extension is left in place, other schemas may use it
+DROP INDEX IF EXISTS idx_team_members_username_github_trgm;
+DROP INDEX IF EXISTS idx_team_members_name_trgm;
@@ -0,0 +1,18 @@
+-- Creating pg_trgm needs privileges the database user may lack, searches skip
+-- typo tolerance without it, so its absence does not fail the migration.
+DO $$
+BEGIN
+	CREATE EXTENSION IF NOT EXISTS pg_trgm;
+EXCEPTION WHEN OTHERS THEN
+	RAISE NOTICE 'pg_trgm not created, trigram search disabled: %', SQLERRM;
+END
+$$;
+
+DO $$
+BEGIN
+	IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm') THEN
+		CREATE INDEX IF NOT EXISTS idx_team_members_name_trgm ON team_members USING GIN (name gin_trgm_ops);
+		CREATE INDEX IF NOT EXISTS idx_team_members_username_github_trgm ON team_members USING GIN (username_github gin_trgm_ops);
+	END IF;
+END
+$$;
@@ -0,0 +1,5 @@
+-- seeders that already ran, see pkg/seeders
+CREATE TABLE IF NOT EXISTS seed_runs (
+	name text PRIMARY KEY,
+	ran_at timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1 @@
+ALTER TABLE team_members DROP COLUMN IF EXISTS version;
@@ -0,0 +1,2 @@
+-- version is bumped by every write so clients can detect concurrent edits
+ALTER TABLE team_members ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+-- nothing to revert, the grants may have been seeded before this migration ran and
+-- removing them would lock the admins out of restore
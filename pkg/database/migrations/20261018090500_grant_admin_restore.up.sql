@@ -0,0 +1,7 @@
+-- grants the soft delete permissions to the admins of an already seeded database, a database
+-- seeded since then already has them and an empty one is still seeded by pkg/seeders
+INSERT INTO role_permissions (role, permission, created_at, updated_at)
+SELECT 'admin', permission, now(), now()
+FROM (VALUES ('team_member:restore'), ('team_member:read_deleted')) AS grants (permission)
+WHERE EXISTS (SELECT 1 FROM role_permissions)
+ON CONFLICT (role, permission) DO NOTHING;
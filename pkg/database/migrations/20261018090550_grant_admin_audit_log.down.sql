@@ -0,0 +1 @@
+-- nothing to revert, the grant may have been seeded before this migration ran
@@ -0,0 +1,6 @@
+-- grants the audit log permission to the admins of an already seeded database, a database
+-- seeded since then already has it and an empty one is still seeded by pkg/seeders
+INSERT INTO role_permissions (role, permission, created_at, updated_at)
+SELECT 'admin', 'audit_log:read', now(), now()
+WHERE EXISTS (SELECT 1 FROM role_permissions)
+ON CONFLICT (role, permission) DO NOTHING;
@@ -0,0 +1,5 @@
+-- grants the permission guarding /debug/vars to the admins of an already seeded database
+INSERT INTO role_permissions (role, permission, created_at, updated_at)
+SELECT 'admin', 'metrics:read', now(), now()
+WHERE EXISTS (SELECT 1 FROM role_permissions)
+ON CONFLICT (role, permission) DO NOTHING;
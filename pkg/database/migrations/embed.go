@@ -0,0 +1,9 @@
+// Package migrations holds the versioned SQL migrations of the database, applied by pkg/migrate.
+package migrations
+
+import "embed"
+
+// FS holds the <version>_<name>.up.sql and <version>_<name>.down.sql scripts.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,24 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/database/migrations"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/migrate"
+)
+
+func TestMigrations(t *testing.T) {
+	list, err := migrate.Load(migrations.FS)
+	if err != nil {
+		t.Fatalf("migrate.Load() error = %v", err)
+	}
+
+	for _, migration := range list {
+		if migration.Down == "" {
+			t.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+		}
+	}
+	if len(list) == 0 {
+		t.Errorf("migrate.Load() found no migrations")
+	}
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -8,7 +9,8 @@ import (
 	"time"
 
 	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
-	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/database/migrations"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/migrate"
 	"github.com/adamnasrudin03/go-skeleton-mux/pkg/seeders"
 	"github.com/sirupsen/logrus"
 
@@ -23,10 +25,36 @@ var (
 	mu  = &sync.Mutex{}
 )
 
-// SetupDbConnection is creating a new connection to our database
+// SetupDbConnection is creating a new connection to our database, migrating it
+// when DB_IS_MIGRATE is set and seeding it when DB_IS_SEED is set
 func SetupDbConnection(cfg *configs.Configs, logger *logrus.Logger) *gorm.DB {
 	mu.Lock()
 	defer mu.Unlock()
+
+	db = OpenDbConnection(cfg, logger)
+
+	if cfg.DB.DbIsMigrate {
+		_, err = NewMigrator(db, logger).Up(context.Background(), 0)
+		if err != nil {
+			logger.Panicf("Failed to migrate database , %v", err)
+			return nil
+		}
+	}
+
+	if cfg.DB.DbIsSeed {
+		_, err = seeders.NewRegistry(db, cfg.App.Env, logger, seeders.Default()...).Run(context.Background(), false)
+		if err != nil {
+			logger.Panicf("Failed to seed database , %v", err)
+			return nil
+		}
+	}
+
+	logger.Info("Connection Database Success!")
+	return db
+}
+
+// OpenDbConnection is creating a new connection to our database without migrating nor seeding it
+func OpenDbConnection(cfg *configs.Configs, logger *logrus.Logger) *gorm.DB {
 	logLevel := gormLogger.Silent
 	if cfg.App.Env == "dev" {
 		logLevel = gormLogger.Info
@@ -54,12 +82,18 @@ func SetupDbConnection(cfg *configs.Configs, logger *logrus.Logger) *gorm.DB {
 		cfg.DB.DbName,
 		cfg.DB.Port)
 
-	db, err = gorm.Open(postgres.Open(dsn), gormConfig)
+	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
 	if err != nil {
 		logger.Panicf("Failed to create a connection to database , %v", err)
 		return nil
 	}
 
+	err = RegisterCallbacks(db)
+	if err != nil {
+		logger.Panicf("Failed to register database callbacks , %v", err)
+		return nil
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		logger.Panicf("Failed to check connection to database , %v", err)
@@ -70,30 +104,35 @@ func SetupDbConnection(cfg *configs.Configs, logger *logrus.Logger) *gorm.DB {
 		return nil
 	}
 
-	if cfg.DB.DbIsMigrate {
-		//auto migration entity db
-		db.AutoMigrate(
-			&models.TeamMember{},
-		)
+	return db
+}
+
+// NewMigrator returns the migrator of the embedded migrations, it panics when they cannot be loaded
+func NewMigrator(db *gorm.DB, logger *logrus.Logger) *migrate.Migrator {
+	sqlDB, err := db.DB()
+	if err != nil {
+		logger.Panicf("Failed to check connection to database , %v", err)
+		return nil
 	}
 
-	go func(db *gorm.DB) {
-		seeders.InitTeamMembers(db)
-	}(db)
+	list, err := migrate.Load(migrations.FS)
+	if err != nil {
+		logger.Panicf("Failed to load migrations , %v", err)
+		return nil
+	}
 
-	logger.Info("Connection Database Success!")
-	return db
+	return migrate.New(migrate.NewPostgresStore(sqlDB), list, logger)
 }
 
 // CloseDbConnection method is closing a connection between your app and your db
-func CloseDbConnection(db *gorm.DB, logger *logrus.Logger) {
+func CloseDbConnection(db *gorm.DB, logger *logrus.Logger) error {
 	dbSQL, err := db.DB()
 	if err != nil {
-		logger.Panicf("Failed to close connection from database, %v", err)
-		return
+		logger.Errorf("Failed to close connection from database, %v", err)
+		return err
 	}
 
-	dbSQL.Close()
+	return dbSQL.Close()
 }
 
 func GetDB() *gorm.DB {
@@ -1,16 +1,31 @@
 package driver
 
 import (
+	"io"
 	"time"
 
 	help "github.com/adamnasrudin03/go-helpers"
 	"github.com/go-redis/redis"
 )
 
+// scanCount is the number of keys DelPattern asks SCAN to walk per call.
+const scanCount = 500
+
+// ErrNil is returned by Get for a key that does not exist.
+var ErrNil = redis.Nil
+
 type RedisClient interface {
 	Del(key string) error
+	// DelPattern deletes the keys matching the glob pattern and returns how many were deleted.
+	DelPattern(pattern string) (int64, error)
 	Set(key string, value interface{}, expDur time.Duration) error
+	// SetNX sets the key only when it does not exist and reports whether it did.
+	SetNX(key string, value interface{}, expDur time.Duration) (bool, error)
+	// Incr adds one to the number stored under key, 0 when it does not exist, and returns it.
+	Incr(key string) (int64, error)
 	Get(key string) (string, error)
+	Ping() error
+	Close() error
 }
 
 type redisCtx struct {
@@ -33,8 +48,40 @@ func (c *redisCtx) Del(key string) error {
 	return nil
 }
 
+func (c *redisCtx) DelPattern(pattern string) (int64, error) {
+	var (
+		deleted int64
+		cursor  uint64
+	)
+	// SCAN walks the keyspace in steps instead of blocking the server as KEYS does
+	for {
+		keys, next, err := c.redisClient.Scan(cursor, pattern, scanCount).Result()
+		if err != nil {
+			logger.Error(err)
+			return deleted, err
+		}
+
+		if len(keys) > 0 {
+			n, err := c.redisClient.Del(keys...).Result()
+			if err != nil {
+				logger.Error(err)
+				return deleted, err
+			}
+			deleted += n
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
+
 func (c *redisCtx) Get(key string) (string, error) {
 	data, err := c.redisClient.Get(key).Result()
+	if err == ErrNil {
+		return "", err
+	}
 	if err != nil {
 		logger.Error(err)
 		return "", err
@@ -58,6 +105,32 @@ func (c *redisCtx) Set(key string, value interface{}, expDur time.Duration) erro
 	return nil
 }
 
+func (c *redisCtx) SetNX(key string, value interface{}, expDur time.Duration) (bool, error) {
+	payload, err := help.SafeJsonMarshal(value)
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	ok, err := c.redisClient.SetNX(key, payload, expDur).Result()
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (c *redisCtx) Incr(key string) (int64, error) {
+	n, err := c.redisClient.Incr(key).Result()
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return n, nil
+}
+
 func (c *redisCtx) Keys(key string) ([]string, error) {
 	data, err := c.redisClient.Keys(key).Result()
 	if err != nil {
@@ -66,3 +139,28 @@ func (c *redisCtx) Keys(key string) ([]string, error) {
 	}
 	return data, nil
 }
+
+func (c *redisCtx) Ping() error {
+	err := c.redisClient.Ping().Err()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *redisCtx) Close() error {
+	closer, ok := c.redisClient.(io.Closer)
+	if !ok {
+		return nil
+	}
+
+	err := closer.Close()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Manager owns the process lifecycle: it traps SIGINT/SIGTERM, drains the
+// http server, waits for tracked background goroutines and then runs the
+// registered closers in the order they were registered.
+type Manager struct {
+	Timeout time.Duration
+	Delay   time.Duration
+	Logger  *logrus.Logger
+
+	wg           sync.WaitGroup
+	waiting      bool
+	mu           sync.Mutex
+	closers      []closer
+	shuttingDown atomic.Bool
+}
+
+type closer struct {
+	name string
+	fn   func() error
+}
+
+// NewManager creates a Manager. delay is how long the server keeps serving
+// with IsShuttingDown reporting true before draining starts, so load
+// balancers can observe a failing readiness probe first.
+func NewManager(timeout, delay time.Duration, logger *logrus.Logger) *Manager {
+	return &Manager{
+		Timeout: timeout,
+		Delay:   delay,
+		Logger:  logger,
+	}
+}
+
+// Go runs fn in a goroutine that shutdown waits for before closing resources.
+// Goroutines started after the wait has begun are no longer tracked.
+func (m *Manager) Go(fn func()) {
+	m.mu.Lock()
+	if m.waiting {
+		m.mu.Unlock()
+		go fn()
+		return
+	}
+	m.wg.Add(1)
+	m.mu.Unlock()
+
+	go func() {
+		defer m.wg.Done()
+		fn()
+	}()
+}
+
+// OnShutdown registers a resource to be closed once the server is drained.
+func (m *Manager) OnShutdown(name string, fn func() error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closers = append(m.closers, closer{name: name, fn: fn})
+}
+
+// IsShuttingDown reports whether a shutdown has started.
+func (m *Manager) IsShuttingDown() bool {
+	return m.shuttingDown.Load()
+}
+
+// ListenAndServe starts the server and blocks until it fails or a shutdown signal is received.
+func (m *Manager) ListenAndServe(server *http.Server) error {
+	return m.run(server, server.ListenAndServe)
+}
+
+// Serve is like ListenAndServe but accepts connections on an existing listener.
+func (m *Manager) Serve(server *http.Server, ln net.Listener) error {
+	return m.run(server, func() error {
+		return server.Serve(ln)
+	})
+}
+
+func (m *Manager) run(server *http.Server, serve func() error) error {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	errServe := make(chan error, 1)
+	go func() {
+		errServe <- serve()
+	}()
+
+	select {
+	case err := <-errServe:
+		if !errors.Is(err, http.ErrServerClosed) {
+			m.Logger.Errorf("Lifecycle-run server error: %v ", err)
+			m.closeAll()
+			return err
+		}
+	case sig := <-quit:
+		m.Logger.Infof("Lifecycle-run received signal %v, shutting down", sig)
+		m.shuttingDown.Store(true)
+		time.Sleep(m.Delay)
+	}
+
+	return m.Shutdown(server)
+}
+
+// Shutdown drains the server within the configured timeout, waits for tracked
+// goroutines and closes the registered resources.
+func (m *Manager) Shutdown(server *http.Server) error {
+	var (
+		opName = "Lifecycle-Shutdown"
+		err    error
+	)
+	m.shuttingDown.Store(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+	defer cancel()
+
+	if server != nil {
+		err = server.Shutdown(ctx)
+		if err != nil {
+			m.Logger.Errorf("%v drain server error: %v ", opName, err)
+		}
+	}
+
+	errWait := m.wait(ctx)
+	if errWait != nil {
+		m.Logger.Errorf("%v wait background tasks error: %v ", opName, errWait)
+		err = errors.Join(err, errWait)
+	}
+
+	return errors.Join(err, m.closeAll())
+}
+
+func (m *Manager) wait(ctx context.Context) error {
+	m.mu.Lock()
+	m.waiting = true
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *Manager) closeAll() error {
+	m.mu.Lock()
+	closers := m.closers
+	m.closers = nil
+	m.mu.Unlock()
+
+	var errs error
+	for _, c := range closers {
+		if err := c.fn(); err != nil {
+			m.Logger.Errorf("Lifecycle-closeAll close %v error: %v ", c.name, err)
+			errs = errors.Join(errs, err)
+			continue
+		}
+		m.Logger.Infof("Lifecycle-closeAll %v closed", c.name)
+	}
+
+	return errs
+}
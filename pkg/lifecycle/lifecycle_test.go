@@ -0,0 +1,184 @@
+package lifecycle
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type recorder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (r *recorder) add(step string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.steps...)
+}
+
+func TestManager_Serve(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		handlerWait time.Duration
+		wantStatus  int
+		wantSteps   []string
+		wantErr     bool
+	}{
+		{
+			name:        "drain in-flight request then close resources in order",
+			timeout:     2 * time.Second,
+			handlerWait: 200 * time.Millisecond,
+			wantStatus:  http.StatusOK,
+			wantSteps:   []string{"request", "background", "postgres", "redis"},
+			wantErr:     false,
+		},
+		{
+			name:        "deadline exceeded still closes resources",
+			timeout:     50 * time.Millisecond,
+			handlerWait: 500 * time.Millisecond,
+			wantStatus:  http.StatusOK,
+			wantSteps:   []string{"postgres", "redis"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rec     = &recorder{}
+				logger  = logrus.New()
+				m       = NewManager(tt.timeout, 0, logger)
+				started = make(chan struct{})
+			)
+			logger.SetOutput(io.Discard)
+
+			ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				m.Go(func() {
+					time.Sleep(tt.handlerWait + 50*time.Millisecond)
+					rec.add("background")
+				})
+				time.Sleep(tt.handlerWait)
+				rec.add("request")
+				w.WriteHeader(http.StatusOK)
+			}))
+			m.OnShutdown("postgres", func() error {
+				rec.add("postgres")
+				return nil
+			})
+			m.OnShutdown("redis", func() error {
+				rec.add("redis")
+				return nil
+			})
+
+			errServe := make(chan error, 1)
+			go func() {
+				errServe <- m.Serve(ts.Config, ts.Listener)
+			}()
+
+			status := make(chan int, 1)
+			go func() {
+				resp, err := http.Get("http://" + ts.Listener.Addr().String())
+				if err != nil {
+					status <- 0
+					return
+				}
+				defer resp.Body.Close()
+				status <- resp.StatusCode
+			}()
+
+			<-started
+			if m.IsShuttingDown() {
+				t.Errorf("Manager.IsShuttingDown() = true before signal")
+			}
+			if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+				t.Fatalf("failed send signal: %v", err)
+			}
+
+			err := <-errServe
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.Serve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !m.IsShuttingDown() {
+				t.Errorf("Manager.IsShuttingDown() = false after shutdown")
+			}
+			if got := rec.get(); !reflect.DeepEqual(got, tt.wantSteps) {
+				t.Errorf("Manager.Serve() steps = %v, want %v", got, tt.wantSteps)
+			}
+			if got := <-status; got != tt.wantStatus {
+				t.Errorf("Manager.Serve() response status = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestManager_Serve_ServerError(t *testing.T) {
+	var (
+		logger = logrus.New()
+		m      = NewManager(time.Second, 0, logger)
+		closed bool
+	)
+	logger.SetOutput(io.Discard)
+
+	ts := httptest.NewUnstartedServer(http.NotFoundHandler())
+	ts.Listener.Close()
+	m.OnShutdown("redis", func() error {
+		closed = true
+		return nil
+	})
+
+	err := m.Serve(ts.Config, ts.Listener)
+	if err == nil {
+		t.Errorf("Manager.Serve() error = nil, want error")
+	}
+	if !closed {
+		t.Errorf("Manager.Serve() did not close registered resources")
+	}
+}
+
+func TestManager_Shutdown_CloserError(t *testing.T) {
+	var (
+		logger = logrus.New()
+		m      = NewManager(time.Second, 0, logger)
+		errMsg = errors.New("close failed")
+		steps  []string
+	)
+	logger.SetOutput(io.Discard)
+
+	m.OnShutdown("postgres", func() error {
+		steps = append(steps, "postgres")
+		return errMsg
+	})
+	m.OnShutdown("redis", func() error {
+		steps = append(steps, "redis")
+		return nil
+	})
+
+	err := m.Shutdown(&http.Server{})
+	if !errors.Is(err, errMsg) {
+		t.Errorf("Manager.Shutdown() error = %v, want %v", err, errMsg)
+	}
+	if want := []string{"postgres", "redis"}; !reflect.DeepEqual(steps, want) {
+		t.Errorf("Manager.Shutdown() steps = %v, want %v", steps, want)
+	}
+
+	// closers run only once
+	if err := m.Shutdown(nil); err != nil {
+		t.Errorf("Manager.Shutdown() second call error = %v, want nil", err)
+	}
+}
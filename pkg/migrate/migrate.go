@@ -0,0 +1,263 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// VersionLayout formats the version of a new migration from its creation time.
+const VersionLayout = "20060102150405"
+
+var (
+	ErrNoDown = errors.New("migration has no down script")
+
+	fileName    = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	nameReplace = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Migration is a pair of up and down SQL scripts identified by Version.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Record is a migration applied to the database.
+type Record struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Status is a known migration with the time it was applied, Missing marks
+// a migration applied to the database whose files are gone.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	Missing   bool
+}
+
+// Store runs migrations against a database and keeps track of the applied ones.
+type Store interface {
+	// Lock blocks until no other process holds the migration lock.
+	Lock(ctx context.Context) error
+	Unlock(ctx context.Context) error
+	Applied(ctx context.Context) ([]Record, error)
+	// Apply runs the up script and records the migration in one transaction.
+	Apply(ctx context.Context, migration Migration) error
+	// Revert runs the down script and forgets the migration in one transaction.
+	Revert(ctx context.Context, migration Migration) error
+}
+
+// Load reads the migrations of fsys named <version>_<name>.up.sql and
+// <version>_<name>.down.sql, sorted by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s: name is not <version>_<name>.(up|down).sql", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+
+		raw, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d: named both %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(raw)
+		} else {
+			migration.Down = string(raw)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s: missing up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Create writes the empty up and down scripts of a new migration into dir.
+func Create(dir, name string, now time.Time) ([]string, error) {
+	name = strings.Trim(nameReplace.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("migration name is required")
+	}
+
+	version := now.UTC().Format(VersionLayout)
+	paths := []string{
+		filepath.Join(dir, fmt.Sprintf("%s_%s.up.sql", version, name)),
+		filepath.Join(dir, fmt.Sprintf("%s_%s.down.sql", version, name)),
+	}
+	for _, path := range paths {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		if err := file.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
+
+// Migrator applies and reverts Migrations through Store, holding its lock
+// so a single process migrates at a time.
+type Migrator struct {
+	Store      Store
+	Migrations []Migration
+	Logger     *logrus.Logger
+}
+
+func New(store Store, migrations []Migration, logger *logrus.Logger) *Migrator {
+	return &Migrator{
+		Store:      store,
+		Migrations: migrations,
+		Logger:     logger,
+	}
+}
+
+// Up applies up to steps pending migrations in version order, all of them when steps is 0 or less.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(applied map[int64]Record) error {
+		for _, migration := range m.Migrations {
+			if steps > 0 && len(done) == steps {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			if err := m.Store.Apply(ctx, migration); err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			m.Logger.Infof("Migration %d_%s applied", migration.Version, migration.Name)
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(applied map[int64]Record) error {
+		for i := len(m.Migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.Migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, ErrNoDown)
+			}
+
+			if err := m.Store.Revert(ctx, migration); err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+			m.Logger.Infof("Migration %d_%s reverted", migration.Version, migration.Name)
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Status lists every migration in version order with the time it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(applied map[int64]Record) error {
+		for _, migration := range m.Migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if record, ok := applied[migration.Version]; ok {
+				appliedAt := record.AppliedAt
+				status.AppliedAt = &appliedAt
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+
+		for _, record := range applied {
+			appliedAt := record.AppliedAt
+			statuses = append(statuses, Status{Version: record.Version, Name: record.Name, AppliedAt: &appliedAt, Missing: true})
+		}
+		return nil
+	})
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, err
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(applied map[int64]Record) error) (err error) {
+	err = m.Store.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := m.Store.Unlock(ctx); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	records, err := m.Store.Applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[int64]Record, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return fn(applied)
+}
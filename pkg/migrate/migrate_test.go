@@ -0,0 +1,269 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// memoryStore keeps applied migrations in memory and records the calls made to it.
+type memoryStore struct {
+	applied  map[int64]Record
+	calls    []string
+	failOn   int64
+	isLocked bool
+}
+
+func newMemoryStore(versions ...int64) *memoryStore {
+	s := &memoryStore{applied: map[int64]Record{}}
+	for _, version := range versions {
+		s.applied[version] = Record{Version: version, Name: "applied", AppliedAt: time.Unix(version, 0)}
+	}
+	return s
+}
+
+func (s *memoryStore) Lock(ctx context.Context) error {
+	s.isLocked = true
+	s.calls = append(s.calls, "lock")
+	return nil
+}
+
+func (s *memoryStore) Unlock(ctx context.Context) error {
+	s.isLocked = false
+	s.calls = append(s.calls, "unlock")
+	return nil
+}
+
+func (s *memoryStore) Applied(ctx context.Context) ([]Record, error) {
+	var records []Record
+	for _, record := range s.applied {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (s *memoryStore) Apply(ctx context.Context, migration Migration) error {
+	if !s.isLocked {
+		return errors.New("not locked")
+	}
+	if migration.Version == s.failOn {
+		return errors.New("syntax error")
+	}
+	s.applied[migration.Version] = Record{Version: migration.Version, Name: migration.Name}
+	s.calls = append(s.calls, "up "+migration.Name)
+	return nil
+}
+
+func (s *memoryStore) Revert(ctx context.Context, migration Migration) error {
+	if !s.isLocked {
+		return errors.New("not locked")
+	}
+	delete(s.applied, migration.Version)
+	s.calls = append(s.calls, "down "+migration.Name)
+	return nil
+}
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "one", Up: "CREATE TABLE one ();", Down: "DROP TABLE one;"},
+	{Version: 2, Name: "two", Up: "CREATE TABLE two ();", Down: "DROP TABLE two;"},
+	{Version: 3, Name: "three", Up: "CREATE TABLE three ();"},
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"2_two.up.sql":    {Data: []byte("CREATE TABLE two ();")},
+				"2_two.down.sql":  {Data: []byte("DROP TABLE two;")},
+				"1_one.up.sql":    {Data: []byte("CREATE TABLE one ();")},
+				"1_one.down.sql":  {Data: []byte("DROP TABLE one;")},
+				"3_three.up.sql":  {Data: []byte("CREATE TABLE three ();")},
+				"embed.go":        {Data: []byte("package migrations")},
+				"notes/readme.md": {Data: []byte("ignored")},
+			},
+			want: testMigrations,
+		},
+		{
+			name:    "invalid name",
+			fsys:    fstest.MapFS{"one.up.sql": {Data: []byte("SELECT 1;")}},
+			wantErr: true,
+		},
+		{
+			name: "version with two names",
+			fsys: fstest.MapFS{
+				"1_one.up.sql":   {Data: []byte("SELECT 1;")},
+				"1_other.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing up",
+			fsys:    fstest.MapFS{"1_one.down.sql": {Data: []byte("SELECT 1;")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	tests := []struct {
+		name      string
+		store     *memoryStore
+		steps     int
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:      "all pending",
+			store:     newMemoryStore(1),
+			wantCalls: []string{"lock", "up two", "up three", "unlock"},
+		},
+		{
+			name:      "steps",
+			store:     newMemoryStore(),
+			steps:     2,
+			wantCalls: []string{"lock", "up one", "up two", "unlock"},
+		},
+		{
+			name:      "nothing pending",
+			store:     newMemoryStore(1, 2, 3),
+			wantCalls: []string{"lock", "unlock"},
+		},
+		{
+			name:      "stops at the failed migration",
+			store:     &memoryStore{applied: map[int64]Record{}, failOn: 2},
+			wantCalls: []string{"lock", "up one", "unlock"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.store, testMigrations, logrus.New()).Up(context.Background(), tt.steps)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Migrator.Up() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.store.calls, tt.wantCalls) {
+				t.Errorf("Migrator.Up() calls = %v, want %v", tt.store.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	tests := []struct {
+		name      string
+		store     *memoryStore
+		steps     int
+		wantCalls []string
+		wantErr   error
+	}{
+		{
+			name:      "newest first",
+			store:     newMemoryStore(1, 2),
+			steps:     5,
+			wantCalls: []string{"lock", "down two", "down one", "unlock"},
+		},
+		{
+			name:      "one step",
+			store:     newMemoryStore(1, 2),
+			steps:     1,
+			wantCalls: []string{"lock", "down two", "unlock"},
+		},
+		{
+			name:      "no down script",
+			store:     newMemoryStore(1, 2, 3),
+			steps:     1,
+			wantCalls: []string{"lock", "unlock"},
+			wantErr:   ErrNoDown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.store, testMigrations, logrus.New()).Down(context.Background(), tt.steps)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Migrator.Down() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.store.calls, tt.wantCalls) {
+				t.Errorf("Migrator.Down() calls = %v, want %v", tt.store.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	store := newMemoryStore(1, 9)
+	got, err := New(store, testMigrations, logrus.New()).Status(context.Background())
+	if err != nil {
+		t.Fatalf("Migrator.Status() error = %v", err)
+	}
+
+	appliedOne, appliedNine := time.Unix(1, 0), time.Unix(9, 0)
+	want := []Status{
+		{Version: 1, Name: "one", AppliedAt: &appliedOne},
+		{Version: 2, Name: "two"},
+		{Version: 3, Name: "three"},
+		{Version: 9, Name: "applied", AppliedAt: &appliedNine, Missing: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Migrator.Status() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	got, err := Create(dir, "Add Team-Member index", now)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "20261018093000_add_team_member_index.up.sql"),
+		filepath.Join(dir, "20261018093000_add_team_member_index.down.sql"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Create() = %v, want %v", got, want)
+	}
+	for _, path := range want {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Create() file %s: %v", path, err)
+		}
+	}
+
+	if _, err := Create(dir, "add team member index", now); err == nil {
+		t.Errorf("Create() existing migration error = nil, want error")
+	}
+	if _, err := Create(dir, " - ", now); err == nil {
+		t.Errorf("Create() empty name error = nil, want error")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err == nil || migrations != nil {
+		t.Errorf("Load() of empty scripts = %v, %v, want missing up error", migrations, err)
+	}
+}
@@ -0,0 +1,103 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// lockKey is hashed into the advisory lock held while migrating.
+const lockKey = "schema_migrations"
+
+// PostgresStore records migrations in schema_migrations and serializes migrating
+// processes with a session advisory lock, held on a connection of its own.
+type PostgresStore struct {
+	DB *sql.DB
+
+	conn *sql.Conn
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{DB: db}
+}
+
+func (s *PostgresStore) Lock(ctx context.Context) error {
+	conn, err := s.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock(hashtext($1))", lockKey)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	s.conn = conn
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		s.Unlock(ctx)
+		return err
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) Unlock(ctx context.Context) error {
+	if s.conn == nil {
+		return nil
+	}
+	conn := s.conn
+	s.conn = nil
+
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", lockKey)
+	return errors.Join(err, conn.Close())
+}
+
+func (s *PostgresStore) Applied(ctx context.Context) ([]Record, error) {
+	rows, err := s.conn.QueryContext(ctx, "SELECT version, name, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		if err := rows.Scan(&record.Version, &record.Name, &record.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+func (s *PostgresStore) Apply(ctx context.Context, migration Migration) error {
+	return s.inTx(ctx, migration.Up, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+}
+
+func (s *PostgresStore) Revert(ctx context.Context, migration Migration) error {
+	return s.inTx(ctx, migration.Down, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+}
+
+// inTx runs script, which may hold several statements, then record in one transaction.
+func (s *PostgresStore) inTx(ctx context.Context, script string, record string, args ...interface{}) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
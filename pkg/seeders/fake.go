@@ -0,0 +1,56 @@
+package seeders
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/brianvoe/gofakeit/v7"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const fakeBatchSize = 500
+
+var usernameReplace = regexp.MustCompile(`[^a-z0-9]+`)
+
+// FakeTeamMembers generates count realistic team members with faker, a random suffix
+// keeps their emails and usernames apart. The same faker seed generates the same members.
+func FakeTeamMembers(faker *gofakeit.Faker, count int) []models.TeamMember {
+	var (
+		teamMembers = make([]models.TeamMember, 0, count)
+		now         = time.Now()
+	)
+	for i := 0; i < count; i++ {
+		first, last := faker.FirstName(), faker.LastName()
+		username := strings.Trim(usernameReplace.ReplaceAllString(strings.ToLower(first+"-"+last), "-"), "-") +
+			"-" + strings.ToLower(faker.LetterN(6))
+		createdAt := faker.DateRange(now.AddDate(-2, 0, 0), now)
+
+		teamMembers = append(teamMembers, models.TeamMember{
+			Name:           first + " " + last,
+			UsernameGithub: username,
+			Email:          strings.ReplaceAll(username, "-", ".") + "@" + faker.DomainName(),
+			DefaultModel: models.DefaultModel{
+				CreatedAt: createdAt,
+				UpdatedAt: faker.DateRange(createdAt, now),
+			},
+		})
+	}
+
+	return teamMembers
+}
+
+// SeedFakeTeamMembers inserts count team members generated from seed, a random one when 0,
+// in batches and returns how many were inserted, those with a taken email or username are skipped.
+func SeedFakeTeamMembers(ctx context.Context, db *gorm.DB, count int, seed uint64) (int64, error) {
+	teamMembers := FakeTeamMembers(gofakeit.New(seed), count)
+	if len(teamMembers) == 0 {
+		return 0, nil
+	}
+
+	res := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&teamMembers, fakeBatchSize)
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,40 @@
+package seeders
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// LoadFixture decodes the embedded fixtures/<name>.yaml, .yml or .json into v.
+func LoadFixture(name string, v interface{}) error {
+	return loadFixture(fixtures, name, v)
+}
+
+func loadFixture(fsys fs.FS, name string, v interface{}) error {
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		raw, err := fs.ReadFile(fsys, path.Join("fixtures", name+ext))
+		if err != nil {
+			continue
+		}
+
+		if ext == ".json" {
+			err = json.Unmarshal(raw, v)
+		} else {
+			err = yaml.Unmarshal(raw, v)
+		}
+		if err != nil {
+			return fmt.Errorf("fixture %s%s: %w", name, ext, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("fixture %s not found", name)
+}
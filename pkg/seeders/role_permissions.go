@@ -0,0 +1,33 @@
+package seeders
+
+import (
+	"context"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"gorm.io/gorm"
+)
+
+// RolePermissions grants models.DefaultRolePermissions, only into an empty role_permissions
+// so permissions revoked by hand are not granted again. A permission added later reaches
+// the databases seeded before it through a migration, see pkg/database/migrations.
+var RolePermissions = Seeder{
+	Name: "role_permissions",
+	Run: func(ctx context.Context, tx *gorm.DB) error {
+		var rolePermissions = []models.RolePermission{}
+		err := tx.Unscoped().Select("id").Limit(1).Find(&rolePermissions).Error
+		if err != nil || len(rolePermissions) > 0 {
+			return err
+		}
+
+		for role, permissions := range models.DefaultRolePermissions {
+			for _, permission := range permissions {
+				rolePermissions = append(rolePermissions, models.RolePermission{
+					Role:       role,
+					Permission: permission,
+				})
+			}
+		}
+
+		return tx.Create(&rolePermissions).Error
+	},
+}
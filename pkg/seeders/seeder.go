@@ -0,0 +1,150 @@
+package seeders
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	EnvDev     = "dev"
+	EnvStaging = "staging"
+	EnvProd    = "prod"
+
+	// lockKey is hashed into the advisory lock held by the transaction of each seeder.
+	lockKey = "seed_runs"
+)
+
+// Seeder fills the database with a named set of rows, it runs once per database
+// and is recorded in seed_runs, so running it again requires force.
+type Seeder struct {
+	Name string
+	// Envs are the APP_ENV values the seeder runs in, all of them when empty.
+	Envs []string
+	Run  func(ctx context.Context, tx *gorm.DB) error
+}
+
+// RunsIn reports whether the seeder runs in env.
+func (s Seeder) RunsIn(env string) bool {
+	return len(s.Envs) == 0 || slices.Contains(s.Envs, env)
+}
+
+// Default are the seeders of the application in the order they run.
+func Default() []Seeder {
+	return []Seeder{
+		RolePermissions,
+		TeamMembers,
+	}
+}
+
+// Registry runs its Seeders in order against DB, skipping those not meant for Env.
+type Registry struct {
+	DB      *gorm.DB
+	Env     string
+	Seeders []Seeder
+	Logger  *logrus.Logger
+}
+
+func NewRegistry(db *gorm.DB, env string, logger *logrus.Logger, seeders ...Seeder) *Registry {
+	return &Registry{
+		DB:      db,
+		Env:     env,
+		Seeders: seeders,
+		Logger:  logger,
+	}
+}
+
+// Select returns the seeders named in registry order, all of those running in Env when
+// none is named. Naming an unknown seeder or one not meant for Env is an error.
+func (r *Registry) Select(names ...string) ([]Seeder, error) {
+	for _, name := range names {
+		if !slices.ContainsFunc(r.Seeders, func(s Seeder) bool { return s.Name == name }) {
+			return nil, fmt.Errorf("seeder %s not found", name)
+		}
+	}
+
+	var selected []Seeder
+	for _, seeder := range r.Seeders {
+		if len(names) > 0 && !slices.Contains(names, seeder.Name) {
+			continue
+		}
+		if !seeder.RunsIn(r.Env) {
+			if len(names) > 0 {
+				return nil, fmt.Errorf("seeder %s does not run in %s", seeder.Name, r.Env)
+			}
+			continue
+		}
+		selected = append(selected, seeder)
+	}
+
+	return selected, nil
+}
+
+// Run runs the selected seeders not recorded yet, all of them when force is set, and returns
+// the names of those that ran. Each seeder runs and is recorded in its own transaction, holding
+// a lock so concurrent processes do not seed twice; it stops at the first failure.
+func (r *Registry) Run(ctx context.Context, force bool, names ...string) ([]string, error) {
+	selected, err := r.Select(names...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []string
+	for _, seeder := range selected {
+		isRan := false
+		err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", lockKey).Error; err != nil {
+				return err
+			}
+
+			if !force {
+				var recorded bool
+				err := tx.Raw("SELECT EXISTS (SELECT 1 FROM seed_runs WHERE name = ?)", seeder.Name).Scan(&recorded).Error
+				if err != nil || recorded {
+					return err
+				}
+			}
+
+			if err := seeder.Run(ctx, tx); err != nil {
+				return err
+			}
+			isRan = true
+
+			return tx.Exec(`INSERT INTO seed_runs (name) VALUES (?)
+				ON CONFLICT (name) DO UPDATE SET ran_at = now()`, seeder.Name).Error
+		})
+		if err != nil {
+			return ran, fmt.Errorf("seeder %s: %w", seeder.Name, err)
+		}
+
+		if isRan {
+			r.Logger.Infof("Seeder %s ran", seeder.Name)
+			ran = append(ran, seeder.Name)
+		}
+	}
+
+	return ran, nil
+}
+
+// Ran returns when each recorded seeder last ran, by name.
+func (r *Registry) Ran(ctx context.Context) (map[string]time.Time, error) {
+	var records []struct {
+		Name  string
+		RanAt time.Time
+	}
+	err := r.DB.WithContext(ctx).Raw("SELECT name, ran_at FROM seed_runs").Scan(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ran := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		ran[record.Name] = record.RanAt
+	}
+
+	return ran, nil
+}
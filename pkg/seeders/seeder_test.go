@@ -0,0 +1,122 @@
+package seeders
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func testSeeder(name string, envs ...string) Seeder {
+	return Seeder{Name: name, Envs: envs, Run: func(ctx context.Context, tx *gorm.DB) error { return nil }}
+}
+
+func seederNames(seeders []Seeder) []string {
+	var names []string
+	for _, seeder := range seeders {
+		names = append(names, seeder.Name)
+	}
+	return names
+}
+
+func TestRegistry_Select(t *testing.T) {
+	list := []Seeder{
+		testSeeder("roles"),
+		testSeeder("members", EnvDev, EnvStaging),
+		testSeeder("demo", EnvDev),
+	}
+
+	tests := []struct {
+		name    string
+		env     string
+		names   []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "dev runs all", env: EnvDev, want: []string{"roles", "members", "demo"}},
+		{name: "prod skips gated seeders", env: EnvProd, want: []string{"roles"}},
+		{name: "named in registry order", env: EnvDev, names: []string{"demo", "roles"}, want: []string{"roles", "demo"}},
+		{name: "named not meant for env", env: EnvStaging, names: []string{"demo"}, wantErr: true},
+		{name: "unknown name", env: EnvDev, names: []string{"unknown"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRegistry(nil, tt.env, logrus.New(), list...).Select(tt.names...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Registry.Select() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(seederNames(got), tt.want) {
+				t.Errorf("Registry.Select() = %v, want %v", seederNames(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFixture(t *testing.T) {
+	want := []teamMemberFixture{{Name: "Adam", UsernameGithub: "adam", Email: "adam@example.com"}}
+	fsys := fstest.MapFS{
+		"fixtures/yaml.yaml": {Data: []byte("- name: Adam\n  username_github: adam\n  email: adam@example.com\n")},
+		"fixtures/json.json": {Data: []byte(`[{"name": "Adam", "username_github": "adam", "email": "adam@example.com"}]`)},
+		"fixtures/bad.yml":   {Data: []byte("name: [")},
+	}
+
+	tests := []struct {
+		name    string
+		fixture string
+		wantErr bool
+	}{
+		{name: "yaml", fixture: "yaml"},
+		{name: "json", fixture: "json"},
+		{name: "invalid", fixture: "bad", wantErr: true},
+		{name: "missing", fixture: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []teamMemberFixture
+			err := loadFixture(fsys, tt.fixture, &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("loadFixture() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, want) {
+				t.Errorf("loadFixture() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	var members []teamMemberFixture
+	if err := LoadFixture("team_members", &members); err != nil || len(members) == 0 {
+		t.Errorf("LoadFixture(team_members) = %v, %v, want members", members, err)
+	}
+}
+
+func TestFakeTeamMembers(t *testing.T) {
+	got := FakeTeamMembers(gofakeit.New(42), 200)
+	if len(got) != 200 {
+		t.Fatalf("FakeTeamMembers() len = %d, want 200", len(got))
+	}
+
+	emails, usernames := map[string]bool{}, map[string]bool{}
+	for _, member := range got {
+		if member.Name == "" || member.Email == "" || member.UsernameGithub == "" {
+			t.Errorf("FakeTeamMembers() member %+v has empty fields", member)
+		}
+		if member.UpdatedAt.Before(member.CreatedAt) {
+			t.Errorf("FakeTeamMembers() member %+v updated before created", member)
+		}
+		emails[member.Email], usernames[member.UsernameGithub] = true, true
+	}
+	if len(emails) != len(got) || len(usernames) != len(got) {
+		t.Errorf("FakeTeamMembers() %d unique emails and %d unique usernames, want %d", len(emails), len(usernames), len(got))
+	}
+
+	again := FakeTeamMembers(gofakeit.New(42), 200)
+	if got[0].Email != again[0].Email || got[199].UsernameGithub != again[199].UsernameGithub {
+		t.Errorf("FakeTeamMembers() with the same seed differ")
+	}
+}
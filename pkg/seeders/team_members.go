@@ -1,24 +1,42 @@
 package seeders
 
 import (
+	"context"
+
 	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func InitTeamMembers(db *gorm.DB) {
-	tx := db.Begin()
-	var teamMembers = []models.TeamMember{}
-	tx.Select("id").Limit(1).Find(&teamMembers)
-	if len(teamMembers) == 0 {
-		teamMembers = []models.TeamMember{
-			{
-				Name:           "Adam Nasrudin",
-				UsernameGithub: "adamnasrudin03",
-				Email:          "adamnasrudin@example.com",
-			},
+// teamMemberFixture is a team member of fixtures/team_members.
+type teamMemberFixture struct {
+	Name           string `json:"name" yaml:"name"`
+	UsernameGithub string `json:"username_github" yaml:"username_github"`
+	Email          string `json:"email" yaml:"email"`
+}
+
+// TeamMembers creates the team members of fixtures/team_members in dev and staging.
+var TeamMembers = Seeder{
+	Name: "team_members",
+	Envs: []string{EnvDev, EnvStaging},
+	Run: func(ctx context.Context, tx *gorm.DB) error {
+		var fixture []teamMemberFixture
+		if err := LoadFixture("team_members", &fixture); err != nil {
+			return err
+		}
+
+		teamMembers := make([]models.TeamMember, 0, len(fixture))
+		for _, member := range fixture {
+			teamMembers = append(teamMembers, models.TeamMember{
+				Name:           member.Name,
+				UsernameGithub: member.UsernameGithub,
+				Email:          member.Email,
+			})
+		}
+		if len(teamMembers) == 0 {
+			return nil
 		}
-		tx.Create(&teamMembers)
-	}
 
-	tx.Commit()
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&teamMembers).Error
+	},
 }
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrNoCursorSecret is returned without a secret, a token signed with an empty key could be forged.
+	ErrNoCursorSecret = errors.New("no cursor secret")
+)
+
+// EncodeCursor signs the json of v with HMAC-SHA256 into an opaque url safe token.
+func EncodeCursor(secret string, v interface{}) (string, error) {
+	if secret == "" {
+		return "", ErrNoCursorSecret
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signCursor(secret, payload)), nil
+}
+
+// DecodeCursor verifies a token made by EncodeCursor and unmarshals it into v,
+// a malformed or tampered token returns ErrInvalidCursor.
+func DecodeCursor(secret string, token string, v interface{}) error {
+	if secret == "" {
+		return ErrNoCursorSecret
+	}
+
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	if !hmac.Equal(signature, signCursor(secret, payload)) {
+		return ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
+}
+
+func signCursor(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
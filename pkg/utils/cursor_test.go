@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testCursor struct {
+	SortBy string `json:"s"`
+	ID     uint64 `json:"id"`
+}
+
+func TestDecodeCursor(t *testing.T) {
+	token, err := EncodeCursor("secret", testCursor{SortBy: "name", ID: 7})
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+	forged, _ := EncodeCursor("secret", testCursor{SortBy: "name", ID: 8})
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name    string
+		secret  string
+		token   string
+		want    testCursor
+		wantErr error
+	}{
+		{
+			name:   "success",
+			secret: "secret",
+			token:  token,
+			want:   testCursor{SortBy: "name", ID: 7},
+		},
+		{
+			name:    "wrong secret",
+			secret:  "other",
+			token:   token,
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "tampered payload",
+			secret:  "secret",
+			token:   forgedPayload + "." + signature,
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "missing signature",
+			secret:  "secret",
+			token:   payload,
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "no secret",
+			secret:  "",
+			token:   token,
+			wantErr: ErrNoCursorSecret,
+		},
+		{
+			name:    "not base64",
+			secret:  "secret",
+			token:   "!!." + signature,
+			wantErr: ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testCursor
+			err := DecodeCursor(tt.secret, tt.token, &got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DecodeCursor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeCursor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeCursor_NoSecret(t *testing.T) {
+	_, err := EncodeCursor("", testCursor{SortBy: "name", ID: 7})
+	if !errors.Is(err, ErrNoCursorSecret) {
+		t.Errorf("EncodeCursor() error = %v, wantErr %v", err, ErrNoCursorSecret)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/database"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/seeders"
+)
+
+const seedUsage = `usage: go-skeleton seed [command] [flags]
+
+commands:
+  [-force] [name ...]         run the seeders of APP_ENV not run yet, or only those named
+  list                        list seeders, the environments they run in and when they ran
+  fake [-count N] [-seed S]   insert N generated team members for load testing, not in prod
+`
+
+// runSeed runs the seed subcommand, args are the arguments following "seed".
+func runSeed(args []string, out io.Writer) error {
+	command := "run"
+	if len(args) > 0 && (args[0] == "list" || args[0] == "fake") {
+		command, args = args[0], args[1:]
+	}
+
+	var (
+		flags = flag.NewFlagSet("seed "+command, flag.ContinueOnError)
+		force = flags.Bool("force", false, "run seeders again even when they already ran")
+		count = flags.Int("count", 1000, "number of team members to generate")
+		seed  = flags.Uint64("seed", 0, "seed of the generator, random when 0")
+	)
+	flags.SetOutput(out)
+	flags.Usage = func() { fmt.Fprint(out, seedUsage) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		ctx      = context.Background()
+		cfg      = configs.GetInstance()
+		logger   = driver.Logger(cfg)
+		db       = database.OpenDbConnection(cfg, logger)
+		registry = seeders.NewRegistry(db, cfg.App.Env, logger, seeders.Default()...)
+	)
+	defer database.CloseDbConnection(db, logger)
+
+	switch command {
+	case "list":
+		ran, err := registry.Ran(ctx)
+		if err != nil {
+			return err
+		}
+		return printSeeders(out, registry.Seeders, ran)
+	case "fake":
+		if cfg.App.Env == seeders.EnvProd {
+			return errors.New("seed fake does not run in prod")
+		}
+		inserted, err := seeders.SeedFakeTeamMembers(ctx, db, *count, *seed)
+		fmt.Fprintf(out, "%d team member(s) inserted\n", inserted)
+		return err
+	default:
+		ran, err := registry.Run(ctx, *force, flags.Args()...)
+		fmt.Fprintf(out, "%d seeder(s) ran\n", len(ran))
+		return err
+	}
+}
+
+func printSeeders(out io.Writer, list []seeders.Seeder, ran map[string]time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tENVIRONMENTS\tRAN AT")
+	for _, seeder := range list {
+		envs := "all"
+		if len(seeder.Envs) > 0 {
+			envs = strings.Join(seeder.Envs, ",")
+		}
+		ranAt := "pending"
+		if at, ok := ran[seeder.Name]; ok {
+			ranAt = at.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", seeder.Name, envs, ranAt)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/router"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/database"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// runServe serves the API until the process is asked to stop.
+func runServe(args []string, out io.Writer) error {
+	if len(args) > 0 {
+		return fmt.Errorf("serve takes no arguments, got %v", args)
+	}
+
+	cfg := configs.GetInstance()
+	if cfg.App.CursorSecret == "" {
+		return errors.New("serve: APP_CURSOR_SECRET or AUTH_JWT_SECRET is required to sign list cursors")
+	}
+
+	var (
+		logger               = driver.Logger(cfg)
+		cache                = driver.Redis(cfg)
+		validate             = validator.New()
+		lc                   = lifecycle.NewManager(cfg.App.ShutdownTimeout, cfg.App.ShutdownDelay, logger)
+		db          *gorm.DB = database.SetupDbConnection(cfg, logger)
+		repo                 = app.WiringRepository(db, &cache, cfg, logger)
+		services             = app.WiringService(repo, &cache, cfg, logger, lc, validate)
+		guard                = app.WiringGuard(repo, services, &cache, cfg, logger)
+		controllers          = app.WiringController(services, cfg, logger, validate, guard)
+	)
+
+	// closed in registration order once the server is drained
+	lc.OnShutdown("postgres", func() error {
+		return database.CloseDbConnection(db, logger)
+	})
+	lc.OnShutdown("redis", cache.Close)
+
+	r := router.NewRoutes(*controllers)
+	controllers.TeamMember.Mount(r.HttpServer.PathPrefix("/v1/team-members").Subrouter())
+	controllers.AuditLog.Mount(r.HttpServer.PathPrefix("/v1/audit-logs").Subrouter())
+
+	listen := fmt.Sprintf(":%v", cfg.App.Port)
+	err := r.Run(listen, lc)
+	if err != nil {
+		logger.Errorf("Failed to run server, %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/adamnasrudin03/go-skeleton-mux/app"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/configs"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/dto"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/models"
+	"github.com/adamnasrudin03/go-skeleton-mux/app/service"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/database"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/driver"
+	"github.com/adamnasrudin03/go-skeleton-mux/pkg/lifecycle"
+	"github.com/go-playground/validator/v10"
+)
+
+// runTeamMembers runs the team-members subcommand, args are the arguments following "team-members".
+func runTeamMembers(args []string, out io.Writer) (err error) {
+	command, args, err := subcommand("team-members", args, "import", "export")
+	if err != nil {
+		return err
+	}
+
+	var (
+		flags          = flag.NewFlagSet("team-members "+command, flag.ContinueOnError)
+		format         = flags.String("format", "", "csv or jsonl, read from the file extension when empty")
+		dryRun         = flags.Bool("dry-run", false, "import: only check the rows")
+		output         = flags.String("o", "-", "export: file to write, - for stdout")
+		search         = flags.String("search", "", "export: search as GET /v1/team-members?search=")
+		filter         = flags.String("filter", "", "export: filters as a query string, e.g. filter[name][ilike]=adam")
+		includeDeleted = flags.Bool("include-deleted", false, "export: include deleted team members")
+	)
+	flags.SetOutput(out)
+	flags.Usage = func() {
+		fmt.Fprintf(out, "usage: go-skeleton team-members import [flags] <file|->\n       go-skeleton team-members export [flags]\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		ctx      = models.ContextWithCaller(context.Background(), cliCaller())
+		cfg      = configs.GetInstance()
+		logger   = driver.Logger(cfg)
+		cache    = driver.Redis(cfg)
+		db       = database.OpenDbConnection(cfg, logger)
+		lc       = lifecycle.NewManager(cfg.App.ShutdownTimeout, 0, logger)
+		repo     = app.WiringRepository(db, &cache, cfg, logger)
+		services = app.WiringService(repo, &cache, cfg, logger, lc, validator.New())
+	)
+	// the cache invalidations started by the writes finish before Redis and Postgres are closed
+	lc.OnShutdown("postgres", func() error {
+		return database.CloseDbConnection(db, logger)
+	})
+	lc.OnShutdown("redis", cache.Close)
+	defer func() {
+		err = errors.Join(err, lc.Shutdown(nil))
+	}()
+
+	if command == "import" {
+		if flags.NArg() != 1 {
+			return errors.New("team-members import: file is required, - for stdin")
+		}
+		return importTeamMembers(ctx, services.TeamMember, flags.Arg(0), *format, *dryRun, out)
+	}
+
+	query, err := url.ParseQuery(*filter)
+	if err != nil {
+		return fmt.Errorf("team-members export: filter: %w", err)
+	}
+	req := dto.TeamMemberListReq{Search: *search, Query: query, IncludeDeleted: *includeDeleted}
+	return exportTeamMembers(ctx, services.TeamMember, req, *output, *format, out)
+}
+
+func importTeamMembers(ctx context.Context, srv service.TeamMemberService, path, format string, dryRun bool, out io.Writer) error {
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	rows, err := dto.NewTeamMemberReader(in, fileFormat(format, path))
+	if err != nil {
+		return err
+	}
+
+	report, err := srv.Import(ctx, rows, dryRun)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROW\tSTATUS\tID\tERROR")
+	for _, row := range report.Rows {
+		if row.Status == dto.ImportStatusFailed {
+			fmt.Fprintf(w, "%d\t%s\t\t%s\n", row.Row, row.Status, row.Error)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if report.Truncated {
+		fmt.Fprintf(out, "only the first %d row(s) are listed\n", dto.ImportMaxReportedRows)
+	}
+	fmt.Fprintf(out, "%d row(s): %d created, %d valid, %d failed\n", report.Total, report.Created, report.Valid, report.Failed)
+	if report.Failed > 0 {
+		return fmt.Errorf("%d row(s) failed", report.Failed)
+	}
+	return nil
+}
+
+func exportTeamMembers(ctx context.Context, srv service.TeamMemberService, req dto.TeamMemberListReq, path, format string, out io.Writer) (err error) {
+	file := out
+	if path != "-" {
+		created, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := created.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		file = created
+	}
+
+	writer, err := dto.NewTeamMemberWriter(file, fileFormat(format, path))
+	if err != nil {
+		return err
+	}
+
+	err = srv.Export(ctx, req, writer.Write)
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// fileFormat is format, or read from the extension of path when empty, csv by default.
+func fileFormat(format, path string) string {
+	if format != "" {
+		return format
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return dto.FormatJSONL
+	default:
+		return dto.FormatCSV
+	}
+}
+
+// cliCaller is the caller recorded in the audit log of changes made from the command line.
+func cliCaller() *models.Caller {
+	caller := &models.Caller{Method: models.AuthMethodCLI}
+	if current, err := user.Current(); err == nil {
+		caller.Name = current.Username
+	}
+
+	return caller
+}